@@ -0,0 +1,156 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import "math"
+
+// interpolate position between a and b at fraction t
+func interpolate(a, b Coord, t float64) Coord {
+	c := Coord{a[0] + t*(b[0]-a[0]), a[1] + t*(b[1]-a[1])}
+	if a.HasAlt() && b.HasAlt() {
+		c = append(c, a.Alt()+t*(b.Alt()-a.Alt()))
+	}
+	return c
+}
+
+// shift position along longitude axis
+func shiftLng(c Coord, lng float64) Coord {
+	x := make(Coord, len(c))
+	copy(x, c)
+	x[0] += lng
+	return x
+}
+
+// SplitAntimeridian splits the line string at 180° meridian. Crossing is
+// detected when longitude of consecutive positions differs by more than 180°,
+// crossing position is interpolated at ±180°. The line string is not modified,
+// the line string that does not cross the meridian results in single element
+// MultiLineString.
+func (geo *LineString) SplitAntimeridian() *MultiLineString {
+	if len(geo.Coords) == 0 {
+		return &MultiLineString{Coords: Surface{}}
+	}
+
+	part := Curve{clone(geo.Coords[0])}
+	seq := Surface{}
+
+	for i := 1; i < len(geo.Coords); i++ {
+		a, b := geo.Coords[i-1], geo.Coords[i]
+
+		if math.Abs(b.Lng()-a.Lng()) > 180.0 {
+			edge, shift := 180.0, 360.0
+			if a.Lng() < 0 {
+				edge, shift = -180.0, -360.0
+			}
+
+			x := shiftLng(b, shift)
+			c := interpolate(a, x, (edge-a.Lng())/(x.Lng()-a.Lng()))
+			c[0] = edge
+
+			part = append(part, c)
+			seq = append(seq, part)
+
+			c = clone(c)
+			c[0] = -edge
+			part = Curve{c}
+		}
+
+		part = append(part, clone(b))
+	}
+
+	return &MultiLineString{Coords: append(seq, part)}
+}
+
+// SplitAntimeridian splits the polygon at 180° meridian. Rings are unwrapped
+// into continuous longitude space and clipped by the meridian, the part beyond
+// the meridian is shifted back to the range of [-180°, 180°]. The polygon is
+// not modified, the polygon that does not cross the meridian results in single
+// element MultiPolygon.
+//
+// Note: the concave polygon crossing the meridian multiple times is split
+// into two polygons, parts of each hemisphere are connected along meridian
+// by zero width edges.
+func (geo *Polygon) SplitAntimeridian() *MultiPolygon {
+	if len(geo.Coords) == 0 || len(geo.Coords[0]) == 0 {
+		return &MultiPolygon{Coords: Surfaces{}}
+	}
+
+	ref := geo.Coords[0][0].Lng()
+	rings := make(Surface, len(geo.Coords))
+	lo, hi := ref, ref
+	for i, ring := range geo.Coords {
+		rings[i] = unwrapLng(ring, ref)
+		for _, c := range rings[i] {
+			lo, hi = math.Min(lo, c.Lng()), math.Max(hi, c.Lng())
+		}
+	}
+
+	var edge, shift float64
+	switch {
+	case hi > 180.0:
+		edge, shift = 180.0, -360.0
+	case lo < -180.0:
+		edge, shift = -180.0, 360.0
+	default:
+		return &MultiPolygon{Coords: Surfaces{rings}}
+	}
+
+	seq := Surfaces{}
+	for _, side := range []float64{-shift, shift} {
+		var surface Surface
+		for i, ring := range rings {
+			part := clipRingLng(ring, edge, side > 0)
+			if len(part) < 4 {
+				if i == 0 {
+					break
+				}
+				continue
+			}
+
+			if side == shift {
+				for k := range part {
+					part[k] = shiftLng(part[k], shift)
+				}
+			}
+			surface = append(surface, part)
+		}
+
+		if len(surface) > 0 {
+			seq = append(seq, surface)
+		}
+	}
+
+	return &MultiPolygon{Coords: seq}
+}
+
+// unwrapLng makes longitude of ring continuous, difference of consecutive
+// positions does not exceed 180°. The first position is unwrapped close to ref.
+func unwrapLng(ring Curve, ref float64) Curve {
+	seq := make(Curve, len(ring))
+	prev := ref
+	for i, c := range ring {
+		shift := 360.0 * math.Round((prev-c.Lng())/360.0)
+		seq[i] = shiftLng(c, shift)
+		prev = seq[i].Lng()
+	}
+	return seq
+}
+
+// clipRingLng clips the ring by the meridian using Sutherland–Hodgman algorithm,
+// it keeps either western (lng ≤ edge) or eastern part (lng ≥ edge).
+func clipRingLng(ring Curve, edge float64, west bool) Curve {
+	return clipRingAxis(ring, 0, edge, west)
+}
+
+// clone position
+func clone(c Coord) Coord {
+	x := make(Coord, len(c))
+	copy(x, c)
+	return x
+}
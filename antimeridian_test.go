@@ -0,0 +1,92 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestLineStringSplitAntimeridian(t *testing.T) {
+	geo := geojson.LineString{
+		Coords: geojson.Curve{{170.0, 0.0}, {-170.0, 10.0}, {-160.0, 10.0}, {170.0, 40.0}},
+	}
+
+	seq := geo.SplitAntimeridian()
+
+	it.Then(t).Should(
+		it.Equal(len(seq.Coords), 3),
+		it.Equiv(seq.Coords[0], geojson.Curve{{170.0, 0.0}, {180.0, 5.0}}),
+		it.Equiv(seq.Coords[1], geojson.Curve{{-180.0, 5.0}, {-170.0, 10.0}, {-160.0, 10.0}, {-180.0, 30.0}}),
+		it.Equiv(seq.Coords[2], geojson.Curve{{180.0, 30.0}, {170.0, 40.0}}),
+		it.Equiv(geo.Coords, geojson.Curve{{170.0, 0.0}, {-170.0, 10.0}, {-160.0, 10.0}, {170.0, 40.0}}),
+	)
+}
+
+func TestLineStringSplitAntimeridianNoCrossing(t *testing.T) {
+	geo := geojson.LineString{Coords: coordLineString}
+	seq := geo.SplitAntimeridian()
+
+	it.Then(t).Should(
+		it.Equal(len(seq.Coords), 1),
+		it.Equiv(seq.Coords[0], coordLineString),
+		it.Equal(len((&geojson.LineString{}).SplitAntimeridian().Coords), 0),
+	)
+}
+
+func TestPolygonSplitAntimeridian(t *testing.T) {
+	geo := geojson.Polygon{
+		Coords: geojson.Surface{
+			{{170.0, 0.0}, {-170.0, 0.0}, {-170.0, 10.0}, {170.0, 10.0}, {170.0, 0.0}},
+		},
+	}
+
+	seq := geo.SplitAntimeridian()
+
+	it.Then(t).Should(
+		it.Equal(len(seq.Coords), 2),
+		it.Equiv(seq.Coords[0], geojson.Surface{
+			{{170.0, 0.0}, {180.0, 0.0}, {180.0, 10.0}, {170.0, 10.0}, {170.0, 0.0}},
+		}),
+		it.Equiv(seq.Coords[1], geojson.Surface{
+			{{-180.0, 0.0}, {-170.0, 0.0}, {-170.0, 10.0}, {-180.0, 10.0}, {-180.0, 0.0}},
+		}),
+		it.Equal(geo.Coords[0][1][0], -170.0),
+	)
+}
+
+func TestPolygonSplitAntimeridianWithHole(t *testing.T) {
+	geo := geojson.Polygon{
+		Coords: geojson.Surface{
+			{{170.0, 0.0}, {-170.0, 0.0}, {-170.0, 10.0}, {170.0, 10.0}, {170.0, 0.0}},
+			{{-175.0, 2.0}, {-175.0, 8.0}, {-172.0, 8.0}, {-172.0, 2.0}, {-175.0, 2.0}},
+		},
+	}
+
+	seq := geo.SplitAntimeridian()
+
+	it.Then(t).Should(
+		it.Equal(len(seq.Coords), 2),
+		it.Equal(len(seq.Coords[0]), 1),
+		it.Equal(len(seq.Coords[1]), 2),
+		it.Equiv(seq.Coords[1][1], geo.Coords[1]),
+	)
+}
+
+func TestPolygonSplitAntimeridianNoCrossing(t *testing.T) {
+	geo := geojson.Polygon{Coords: coordPolygonWithHole}
+	seq := geo.SplitAntimeridian()
+
+	it.Then(t).Should(
+		it.Equal(len(seq.Coords), 1),
+		it.Equiv(seq.Coords[0], coordPolygonWithHole),
+	)
+}
@@ -0,0 +1,97 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"encoding/json"
+)
+
+// AnyCollection is a collection of heterogeneous features, which schema of
+// properties is not known upfront. Geometry of each feature is decoded,
+// the properties are retained as raw JSON at Properties and decoded on
+// demand with DecodeProperties.
+//
+//	var c geojson.AnyCollection
+//	json.Unmarshal(data, &c)
+//
+//	var city City
+//	c.DecodeProperties(&c.Features[0], &city)
+//
+// Properties are indexed by the address of the feature within Features,
+// the index is not valid once Features slice is re-allocated or copied.
+type AnyCollection struct {
+	Collection[Feature]
+	Properties map[*Feature]json.RawMessage `json:"-"`
+}
+
+// DecodeProperties decodes raw properties of the feature into the value.
+// It does nothing if the feature has no properties.
+func (c AnyCollection) DecodeProperties(fea *Feature, props any) error {
+	raw, has := c.Properties[fea]
+	if !has || raw == nil {
+		return nil
+	}
+
+	return json.Unmarshal(raw, props)
+}
+
+// MarshalJSON encodes collection to GeoJSON, it is used when the collection
+// has no foreign members, otherwise see EncodeGeoJSON.
+func (c *AnyCollection) MarshalJSON() ([]byte, error) {
+	return c.EncodeGeoJSON(nil)
+}
+
+// UnmarshalJSON decodes collection from GeoJSON, it is used when the collection
+// has no foreign members, otherwise see DecodeGeoJSON.
+func (c *AnyCollection) UnmarshalJSON(b []byte) error {
+	return c.DecodeGeoJSON(b, nil)
+}
+
+// EncodeGeoJSON is a helper function to implement GeoJSON codec,
+// see Collection.EncodeGeoJSON.
+func (c AnyCollection) EncodeGeoJSON(props any) ([]byte, error) {
+	return c.EncodeGeoJSONWith(props)
+}
+
+// EncodeGeoJSONWith is a helper function to implement GeoJSON codec,
+// see Collection.EncodeGeoJSONWith.
+func (c AnyCollection) EncodeGeoJSONWith(props any, opts ...EncodeOption) ([]byte, error) {
+	seq := make([]Of[json.RawMessage], len(c.Features))
+	for i := range c.Features {
+		seq[i] = Of[json.RawMessage]{
+			Feature:    c.Features[i],
+			Properties: c.Properties[&c.Features[i]],
+		}
+	}
+
+	raw := Collection[Of[json.RawMessage]]{BBox: c.BBox, CRS: c.CRS, Features: seq}
+	return raw.EncodeGeoJSONWith(props, opts...)
+}
+
+// DecodeGeoJSON is a helper function to implement GeoJSON codec,
+// see Collection.DecodeGeoJSON.
+func (c *AnyCollection) DecodeGeoJSON(bytes []byte, props interface{}) error {
+	var raw Collection[Of[json.RawMessage]]
+	if err := raw.DecodeGeoJSON(bytes, props); err != nil {
+		return err
+	}
+
+	c.BBox = raw.BBox
+	c.CRS = raw.CRS
+	c.Features = make([]Feature, len(raw.Features))
+	c.Properties = make(map[*Feature]json.RawMessage, len(raw.Features))
+	for i, x := range raw.Features {
+		c.Features[i] = x.Feature
+		if x.Properties != nil {
+			c.Properties[&c.Features[i]] = x.Properties
+		}
+	}
+
+	return nil
+}
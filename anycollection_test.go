@@ -0,0 +1,71 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+const anyCollection = `{"type":"FeatureCollection","features":[` +
+	`{"type":"Feature","id":"[city:hel]","geometry":{"type":"Point","coordinates":[24.9384,60.1699]},"properties":{"name":"Helsinki"}},` +
+	`{"type":"Feature","id":"[lake:saimaa]","geometry":{"type":"Polygon","coordinates":[[[28.0,61.0],[29.0,61.0],[29.0,62.0],[28.0,61.0]]]},"properties":{"area":4400}},` +
+	`{"type":"Feature","geometry":{"type":"LineString","coordinates":[[24.0,60.0],[25.0,61.0]]}}` +
+	`]}`
+
+func TestAnyCollectionDecode(t *testing.T) {
+	var c geojson.AnyCollection
+	err := json.Unmarshal([]byte(anyCollection), &c)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(len(c.Features), 3),
+		it.TypeOf[*geojson.Point](c.Features[0].Geometry),
+		it.TypeOf[*geojson.Polygon](c.Features[1].Geometry),
+		it.TypeOf[*geojson.LineString](c.Features[2].Geometry),
+		it.Equiv(c.BoundingBox(), geojson.BoundingBox{24.0, 60.0, 29.0, 62.0}),
+	)
+
+	var city City
+	var lake struct {
+		Area int `json:"area"`
+	}
+	var none City
+	it.Then(t).Should(
+		it.Nil(c.DecodeProperties(&c.Features[0], &city)),
+		it.Nil(c.DecodeProperties(&c.Features[1], &lake)),
+		it.Nil(c.DecodeProperties(&c.Features[2], &none)),
+		it.Equal(city.Name, "Helsinki"),
+		it.Equal(lake.Area, 4400),
+		it.Equal(none.Name, ""),
+	)
+}
+
+func TestAnyCollectionEncode(t *testing.T) {
+	var c geojson.AnyCollection
+	it.Then(t).Should(
+		it.Nil(json.Unmarshal([]byte(anyCollection), &c)),
+	)
+
+	b, err := json.Marshal(&c)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(b)).Contain(`"properties":{"name":"Helsinki"}`),
+		it.String(string(b)).Contain(`"properties":{"area":4400}`),
+	)
+
+	var d geojson.AnyCollection
+	it.Then(t).Should(
+		it.Nil(json.Unmarshal(b, &d)),
+		it.Equal(len(d.Features), 3),
+		it.Equal(string(d.Properties[&d.Features[1]]), `{"area":4400}`),
+	)
+}
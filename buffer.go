@@ -0,0 +1,263 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import "math"
+
+// Buffer returns polygonal approximation of the area within radiusMeters
+// around the geometry: a regular polygon around Point and a rounded
+// offset around LineString. The segments defines number of vertices per
+// quarter-circle. It returns nil for empty or other geometry types.
+//
+// The buffering is approximate planar one. Positions are projected to
+// local equirectangular plane at the center of geometry, it is accurate
+// for radius small compared to Earth radius, away from poles and
+// the antimeridian. The offset of the line self-intersects if the line
+// turns sharply or loops within the radius, the buffer is the union of
+// circles around positions and rectangles around segments then. It might
+// have holes if the line encloses area, nil is returned if the union
+// fails. Rings of the polygon are closed and follow the right-hand rule,
+// the polygon is valid (see IsValid).
+func Buffer(geo Geometry, radiusMeters float64, segments int) *Polygon {
+	if segments < 1 {
+		segments = 1
+	}
+
+	var seq Curve
+	switch v := geo.(type) {
+	case *Point:
+		if len(v.Coords) < 2 {
+			return nil
+		}
+		seq = Curve{v.Coords}
+	case *LineString:
+		seq = v.Coords
+	default:
+		return nil
+	}
+
+	if len(seq) == 0 {
+		return nil
+	}
+
+	plane := newLocalPlane(seq)
+	pts := make([][2]float64, 0, len(seq))
+	for _, c := range seq {
+		p := plane.project(c)
+		if len(pts) == 0 || pts[len(pts)-1] != p {
+			pts = append(pts, p)
+		}
+	}
+
+	if len(pts) == 1 {
+		return plane.polygon(bufferCircle(pts[0], radiusMeters, segments))
+	}
+
+	if ring, ok := bufferLine(pts, radiusMeters, segments); ok {
+		poly := plane.polygon(ring)
+		if _, err := poly.IsValid(); err == nil {
+			return poly
+		}
+	}
+
+	return bufferUnion(plane, pts, radiusMeters, segments)
+}
+
+// bufferUnion is buffer of the line as union of circles around positions
+// and rectangles around segments, it is used when the offset of the line
+// folds or self-intersects.
+func bufferUnion(plane localPlane, pts [][2]float64, r float64, segments int) *Polygon {
+	seq := make([]*Polygon, 0, 2*len(pts))
+	for i, p := range pts {
+		if i > 0 {
+			a := heading(pts[i-1], p) - math.Pi/2
+			seq = append(seq, plane.polygon([][2]float64{
+				offset(pts[i-1], r, a), offset(p, r, a),
+				offset(p, -r, a), offset(pts[i-1], -r, a),
+			}))
+		}
+
+		seq = append(seq, plane.polygon(bufferCircle(p, r, segments)))
+	}
+
+	// Note: neighbour pieces are merged pairwise, each union is connected
+	//       part of the line buffer.
+	for len(seq) > 1 {
+		next := make([]*Polygon, 0, (len(seq)+1)/2)
+		for i := 0; i < len(seq); i += 2 {
+			if i+1 == len(seq) {
+				next = append(next, seq[i])
+				continue
+			}
+
+			geo, err := UnionPolygons(seq[i], seq[i+1])
+			if err != nil {
+				return nil
+			}
+
+			poly, ok := geo.(*Polygon)
+			if !ok {
+				return nil
+			}
+			next = append(next, poly)
+		}
+		seq = next
+	}
+
+	return seq[0]
+}
+
+// local equirectangular plane in meters
+type localPlane struct{ lng, lat, k float64 }
+
+func newLocalPlane(seq Curve) localPlane {
+	c := boundingBox(seq[0], seq).Center()
+	return localPlane{
+		lng: c.Lng(),
+		lat: c.Lat(),
+		k:   math.Cos(radians(c.Lat())),
+	}
+}
+
+// polygon of the ring at the plane, the ring is closed and counter-clockwise
+func (p localPlane) polygon(ring [][2]float64) *Polygon {
+	curve := make(Curve, 0, len(ring)+1)
+	for _, v := range ring {
+		curve = append(curve, p.unproject(v))
+	}
+	curve = append(curve, clone(curve[0]))
+
+	if curve.planarArea() < 0 {
+		curve.reverse()
+	}
+
+	return &Polygon{Coords: Surface{curve}}
+}
+
+func (p localPlane) project(c Coord) [2]float64 {
+	return [2]float64{
+		radians(c.Lng()-p.lng) * p.k * EarthRadius,
+		radians(c.Lat()-p.lat) * EarthRadius,
+	}
+}
+
+func (p localPlane) unproject(v [2]float64) Coord {
+	return Coord{
+		p.lng + degrees(v[0]/(p.k*EarthRadius)),
+		p.lat + degrees(v[1]/EarthRadius),
+	}
+}
+
+// offset of position p in direction of angle
+func offset(p [2]float64, r, angle float64) [2]float64 {
+	return [2]float64{p[0] + r*math.Cos(angle), p[1] + r*math.Sin(angle)}
+}
+
+// arc around p from angle a to a + sweep, end points are excluded
+func arc(p [2]float64, r, a, sweep float64, segments int) [][2]float64 {
+	step := math.Pi / 2 / float64(segments)
+	n := int(math.Ceil(sweep/step - 1e-9))
+
+	seq := make([][2]float64, 0, n)
+	for k := 1; k < n; k++ {
+		seq = append(seq, offset(p, r, a+sweep*float64(k)/float64(n)))
+	}
+	return seq
+}
+
+func bufferCircle(p [2]float64, r float64, segments int) [][2]float64 {
+	return append([][2]float64{offset(p, r, 0)}, arc(p, r, 0, 2*math.Pi, segments)...)
+}
+
+// bufferLine walks the right side of the line, round cap at the end, the
+// right side of the reversed line and round cap at the start. It fails if
+// the offset of either side folds.
+func bufferLine(pts [][2]float64, r float64, segments int) ([][2]float64, bool) {
+	rev := make([][2]float64, len(pts))
+	for i, p := range pts {
+		rev[len(pts)-1-i] = p
+	}
+
+	θ0 := heading(pts[0], pts[1])
+	θn := heading(pts[len(pts)-2], pts[len(pts)-1])
+
+	right, ok := bufferSide(pts, r, segments)
+	if !ok {
+		return nil, false
+	}
+
+	left, ok := bufferSide(rev, r, segments)
+	if !ok {
+		return nil, false
+	}
+
+	ring := append(right, arc(pts[len(pts)-1], r, θn-math.Pi/2, math.Pi, segments)...)
+	ring = append(ring, left...)
+	ring = append(ring, arc(pts[0], r, θ0+math.Pi/2, math.Pi, segments)...)
+	return ring, true
+}
+
+func heading(a, b [2]float64) float64 {
+	return math.Atan2(b[1]-a[1], b[0]-a[0])
+}
+
+// bufferSide offsets the line to the right side, the outer joins are
+// rounded, the inner joins are mitered. It fails if the miter folds the
+// offset.
+func bufferSide(pts [][2]float64, r float64, segments int) ([][2]float64, bool) {
+	a := heading(pts[0], pts[1])
+	seq := [][2]float64{offset(pts[0], r, a-math.Pi/2)}
+
+	for i := 1; i < len(pts)-1; i++ {
+		b := heading(pts[i], pts[i+1])
+		turn := math.Remainder(b-a, 2*math.Pi)
+		ea := offset(pts[i], r, a-math.Pi/2)
+		sb := offset(pts[i], r, b-math.Pi/2)
+
+		switch {
+		case turn > 0:
+			seq = append(seq, ea)
+			seq = append(seq, arc(pts[i], r, a-math.Pi/2, turn, segments)...)
+			seq = append(seq, sb)
+		case turn < 0:
+			// Note: the miter backs off the vertex along both segments,
+			//       the offset folds if it exceeds half of segment.
+			if 2*r*math.Tan(-turn/2) > min(length(pts[i-1], pts[i]), length(pts[i], pts[i+1])) {
+				return nil, false
+			}
+
+			x, ok := intersectLines(offset(pts[i-1], r, a-math.Pi/2), a, sb, b)
+			if !ok {
+				return nil, false
+			}
+			seq = append(seq, x)
+		default:
+			seq = append(seq, ea)
+		}
+
+		a = b
+	}
+
+	return append(seq, offset(pts[len(pts)-1], r, a-math.Pi/2)), true
+}
+
+func length(a, b [2]float64) float64 {
+	return math.Hypot(b[0]-a[0], b[1]-a[1])
+}
+
+// intersection of lines given by position and heading
+func intersectLines(p [2]float64, a float64, q [2]float64, b float64) ([2]float64, bool) {
+	d := math.Sin(b - a)
+	if math.Abs(d) < 1e-12 {
+		return [2]float64{}, false
+	}
+
+	t := ((q[0]-p[0])*math.Sin(b) - (q[1]-p[1])*math.Cos(b)) / d
+	return [2]float64{p[0] + t*math.Cos(a), p[1] + t*math.Sin(a)}, true
+}
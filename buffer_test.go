@@ -0,0 +1,155 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestBufferPoint(t *testing.T) {
+	geo := geojson.Buffer(&geojson.Point{Coords: coordHelsinki}, 1000.0, 8)
+	ring := geo.Coords[0]
+
+	_, err := geo.IsValid()
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(len(ring), 33),
+		it.Equiv(ring[0], ring[len(ring)-1]),
+		it.Equal(geo.IsClockwise(0), false),
+	)
+
+	for _, c := range ring {
+		it.Then(t).Should(
+			it.True(near(geojson.Distance(coordHelsinki, c), 1000.0, 5.0)),
+		)
+	}
+
+	area := math.Pi * 1000.0 * 1000.0
+	it.Then(t).Should(
+		it.True(near(geo.Area(), area, area*0.01)),
+	)
+}
+
+func TestBufferLineString(t *testing.T) {
+	line := &geojson.LineString{Coords: geojson.Curve{
+		{24.90, 60.17}, {24.95, 60.17}, {24.95, 60.20}, {24.97, 60.18},
+	}}
+	geo := geojson.Buffer(line, 200.0, 4)
+	ring := geo.Coords[0]
+
+	_, err := geo.IsValid()
+	it.Then(t).Should(
+		it.Nil(err),
+		it.True(len(ring) >= 4),
+		it.Equiv(ring[0], ring[len(ring)-1]),
+		it.Equal(geo.IsClockwise(0), false),
+	)
+
+	// probes closer than radius to the line are within buffer
+	for _, c := range line.Coords {
+		it.Then(t).Should(it.True(geo.Contains(c)))
+
+		for _, dist := range []float64{150.0, 250.0} {
+			for bearing := 0.0; bearing < 360.0; bearing += 30.0 {
+				probe := geojson.Destination(c, dist, bearing)
+				_, d := line.NearestPoint(probe)
+				if math.Abs(d-200.0) > 10.0 {
+					it.Then(t).Should(
+						it.Equal(geo.Contains(probe), d < 200.0),
+					)
+				}
+			}
+		}
+	}
+
+	// offset of the ring from the line is about radius
+	for _, c := range ring[:len(ring)-1] {
+		_, d := line.NearestPoint(c)
+		it.Then(t).Should(
+			it.True(near(d, 200.0, 2.0)),
+		)
+	}
+}
+
+// checks that buffer is valid and probes closer than radius to the line
+// are within buffer, the tolerance covers approximation of arcs
+func testBufferValid(t *testing.T, line *geojson.LineString, radius float64) {
+	t.Helper()
+
+	geo := geojson.Buffer(line, radius, 8)
+	it.Then(t).ShouldNot(it.Nil(geo))
+
+	_, err := geo.IsValid()
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(geo.IsClockwise(0), false),
+	)
+
+	for _, c := range line.Coords {
+		for _, dist := range []float64{0.5 * radius, 1.5 * radius} {
+			for bearing := 0.0; bearing < 360.0; bearing += 15.0 {
+				probe := geojson.Destination(c, dist, bearing)
+				_, d := line.NearestPoint(probe)
+				if math.Abs(d-radius) > 0.05*radius {
+					it.Then(t).Should(
+						it.Equal(geo.Contains(probe), d < radius),
+					)
+				}
+			}
+		}
+	}
+}
+
+func TestBufferSelfIntersecting(t *testing.T) {
+	t.Run("Zigzag", func(t *testing.T) {
+		seq := geojson.Curve{}
+		for i := 0; i < 20; i++ {
+			seq = append(seq, geojson.Coord{24.0 + 0.002*float64(i), 60.0 + 0.0003*float64(1-2*(i%2))})
+		}
+		testBufferValid(t, &geojson.LineString{Coords: seq}, 500.0)
+	})
+
+	t.Run("Hairpin", func(t *testing.T) {
+		testBufferValid(t, &geojson.LineString{Coords: geojson.Curve{
+			{24.0, 60.0}, {24.1, 60.0}, {24.0, 60.0001},
+		}}, 1000.0)
+	})
+
+	t.Run("ShortSegment", func(t *testing.T) {
+		testBufferValid(t, &geojson.LineString{Coords: geojson.Curve{
+			{24.0247, 60.0277}, {24.0205, 60.0006}, {24.0179, 60.0016},
+		}}, 1189.0)
+	})
+
+	t.Run("Loop", func(t *testing.T) {
+		line := &geojson.LineString{Coords: geojson.Curve{
+			{24.90, 60.17}, {24.95, 60.17}, {24.95, 60.20}, {24.90, 60.20}, {24.90, 60.16},
+		}}
+		testBufferValid(t, line, 200.0)
+
+		geo := geojson.Buffer(line, 200.0, 8)
+		it.Then(t).Should(
+			it.Equal(len(geo.Coords), 2),
+			it.True(!geo.Contains(geojson.Coord{24.925, 60.185})),
+		)
+	})
+}
+
+func TestBufferUnsupported(t *testing.T) {
+	it.Then(t).Should(
+		it.Equiv(geojson.Buffer(&geojson.Point{}, 1000.0, 8), nil),
+		it.Equiv(geojson.Buffer(&geojson.LineString{}, 1000.0, 8), nil),
+		it.Equiv(geojson.Buffer(&geojson.Polygon{Coords: coordPolygon}, 1000.0, 8), nil),
+		it.Equal(len(geojson.Buffer(&geojson.LineString{Coords: geojson.Curve{coordHelsinki, coordHelsinki}}, 1000.0, 1).Coords[0]), 5),
+	)
+}
@@ -0,0 +1,119 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import "math"
+
+// Centroid of the geometry. It is area-weighted centroid for polygons,
+// length-weighted midpoint for line strings and arithmetic mean for points.
+// Centroid of GeometryCollection is defined by children of the highest
+// dimension, weighted by their measure. Centroid is computed at plane of
+// lng, lat coordinates. It returns empty Coord for nil or empty geometry.
+func Centroid(geo Geometry) Coord {
+	var c centroid
+	c.geometry(geo)
+	return c.value()
+}
+
+// centroid accumulates weighted positions for each dimension
+type centroid struct {
+	// 0-dimensional: points, 1: curves, 2: surfaces
+	x, y, w [3]float64
+}
+
+func (c *centroid) value() Coord {
+	for dim := 2; dim >= 0; dim-- {
+		if c.w[dim] != 0 {
+			return Coord{c.x[dim] / c.w[dim], c.y[dim] / c.w[dim]}
+		}
+	}
+	return Coord{}
+}
+
+func (c *centroid) geometry(geo Geometry) {
+	switch v := geo.(type) {
+	case *Point:
+		c.point(v.Coords)
+	case *MultiPoint:
+		for _, x := range v.Coords {
+			c.point(x)
+		}
+	case *LineString:
+		c.curve(v.Coords)
+	case *MultiLineString:
+		for _, x := range v.Coords {
+			c.curve(x)
+		}
+	case *Polygon:
+		c.surface(v.Coords)
+	case *MultiPolygon:
+		for _, x := range v.Coords {
+			c.surface(x)
+		}
+	case *GeometryCollection:
+		for _, x := range v.Geometries {
+			c.geometry(x)
+		}
+	}
+}
+
+func (c *centroid) point(p Coord) {
+	if len(p) < 2 {
+		return
+	}
+
+	c.x[0] += p.Lng()
+	c.y[0] += p.Lat()
+	c.w[0] += 1
+}
+
+func (c *centroid) curve(seq Curve) {
+	for i, p := range seq {
+		c.point(p)
+
+		if i == 0 || len(p) < 2 || len(seq[i-1]) < 2 {
+			continue
+		}
+
+		a := seq[i-1]
+		d := math.Hypot(p.Lng()-a.Lng(), p.Lat()-a.Lat())
+		c.x[1] += d * (a.Lng() + p.Lng()) / 2
+		c.y[1] += d * (a.Lat() + p.Lat()) / 2
+		c.w[1] += d
+	}
+}
+
+func (c *centroid) surface(seq Surface) {
+	for i, ring := range seq {
+		c.curve(ring)
+
+		var a, x, y float64
+		for k := 1; k < len(ring); k++ {
+			p, q := ring[k-1], ring[k]
+			if len(p) < 2 || len(q) < 2 {
+				continue
+			}
+			cross := p.Lng()*q.Lat() - q.Lng()*p.Lat()
+			a += cross / 2
+			x += (p.Lng() + q.Lng()) * cross / 6
+			y += (p.Lat() + q.Lat()) * cross / 6
+		}
+
+		// exterior ring contributes positive area, holes negative one
+		// regardless of the winding order
+		sign := 1.0
+		if (a < 0) != (i > 0) {
+			sign = -1.0
+		}
+
+		c.x[2] += sign * x
+		c.y[2] += sign * y
+		c.w[2] += sign * a
+	}
+}
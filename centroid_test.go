@@ -0,0 +1,61 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func nearCoord(a, b geojson.Coord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !near(a[i], b[i], 1e-9) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCentroid(t *testing.T) {
+	square := geojson.Curve{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}
+	hole := geojson.Curve{{0, 0}, {0, 2}, {2, 2}, {2, 0}, {0, 0}}
+
+	it.Then(t).Should(
+		it.True(nearCoord(geojson.Centroid(&geojson.Point{Coords: coordPoint}), geojson.Coord{100.0, 0.0})),
+		it.True(nearCoord(geojson.Centroid(&geojson.MultiPoint{Coords: coordMultiPoint}), geojson.Coord{100.5, 0.5})),
+		it.True(nearCoord(geojson.Centroid(&geojson.LineString{
+			Coords: geojson.Curve{{0, 0}, {2, 0}, {2, 1}},
+		}), geojson.Coord{4.0 / 3.0, 1.0 / 6.0})),
+		it.True(nearCoord(geojson.Centroid(&geojson.MultiLineString{Coords: coordMultiLineString}), geojson.Coord{101.5, 1.5})),
+		it.True(nearCoord(geojson.Centroid(&geojson.Polygon{Coords: geojson.Surface{square}}), geojson.Coord{2.0, 2.0})),
+		it.True(nearCoord(geojson.Centroid(&geojson.Polygon{Coords: geojson.Surface{square, hole}}), geojson.Coord{7.0 / 3.0, 7.0 / 3.0})),
+		it.True(nearCoord(geojson.Centroid(&geojson.MultiPolygon{Coords: geojson.Surfaces{
+			{square},
+			{{{10, 0}, {14, 0}, {14, 4}, {10, 4}, {10, 0}}},
+		}}), geojson.Coord{7.0, 2.0})),
+		it.True(nearCoord(geojson.Centroid(&geojson.GeometryCollection{Geometries: []geojson.Geometry{
+			&geojson.Point{Coords: geojson.Coord{100, 100}},
+			&geojson.Polygon{Coords: geojson.Surface{square}},
+		}}), geojson.Coord{2.0, 2.0})),
+	)
+}
+
+func TestCentroidEmpty(t *testing.T) {
+	it.Then(t).Should(
+		it.Equal(len(geojson.Centroid(nil)), 0),
+		it.Equal(len(geojson.Centroid(&geojson.Point{})), 0),
+		it.Equal(len(geojson.Centroid(&geojson.Polygon{})), 0),
+		it.Equal(len(geojson.Centroid(&geojson.GeometryCollection{})), 0),
+	)
+}
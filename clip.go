@@ -0,0 +1,314 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+// Clip returns geometry clipped to the bounding box, or nil when nothing
+// is inside the box. Points inside the box are returned unchanged. Line
+// strings are clipped using Liang–Barsky algorithm, the line string that
+// leaves and re-enters the box results in MultiLineString. Polygons are
+// clipped using Sutherland–Hodgman algorithm, rings remain closed. Clipped
+// concave polygon may have degenerate edges along the boundary of the box.
+func Clip(geo Geometry, bbox BoundingBox) Geometry {
+	if len(bbox) < 4 || geo == nil {
+		return nil
+	}
+
+	switch v := geo.(type) {
+	case *Point:
+		if !bbox.Contains(v.Coords) {
+			return nil
+		}
+		return v
+	case *MultiPoint:
+		seq := Curve{}
+		for _, c := range v.Coords {
+			if bbox.Contains(c) {
+				seq = append(seq, clone(c))
+			}
+		}
+		if len(seq) == 0 {
+			return nil
+		}
+		return &MultiPoint{Coords: seq}
+	case *LineString:
+		seq := clipCurve(v.Coords, bbox)
+		switch len(seq) {
+		case 0:
+			return nil
+		case 1:
+			return &LineString{Coords: seq[0]}
+		default:
+			return &MultiLineString{Coords: seq}
+		}
+	case *MultiLineString:
+		seq := Surface{}
+		for _, line := range v.Coords {
+			seq = append(seq, clipCurve(line, bbox)...)
+		}
+		if len(seq) == 0 {
+			return nil
+		}
+		return &MultiLineString{Coords: seq}
+	case *Polygon:
+		seq := clipSurface(v.Coords, bbox)
+		if seq == nil {
+			return nil
+		}
+		return &Polygon{Coords: seq}
+	case *MultiPolygon:
+		seq := Surfaces{}
+		for _, surface := range v.Coords {
+			if x := clipSurface(surface, bbox); x != nil {
+				seq = append(seq, x)
+			}
+		}
+		if len(seq) == 0 {
+			return nil
+		}
+		return &MultiPolygon{Coords: seq}
+	case *GeometryCollection:
+		seq := []Geometry{}
+		for _, x := range v.Geometries {
+			if c := Clip(x, bbox); c != nil {
+				seq = append(seq, c)
+			}
+		}
+		if len(seq) == 0 {
+			return nil
+		}
+		return &GeometryCollection{Geometries: seq}
+	default:
+		return nil
+	}
+}
+
+// Intersects checks if any part of geometry is within the bounding box or
+// crosses it, the boundary of the box is inclusive. Points are tested for
+// containment, segments of line strings and rings are tested using
+// Liang–Barsky algorithm, the box fully inside of polygon intersects it.
+// It is cheaper than Clip and short-circuits at first intersection, which
+// makes it a refinement of the bounding box test used by Collection.Within.
+func Intersects(geo Geometry, bbox BoundingBox) bool {
+	if len(bbox) < 4 || geo == nil || !bbox.Intersects(geo.BoundingBox()) {
+		return false
+	}
+
+	switch v := geo.(type) {
+	case *Point:
+		return bbox.Contains(v.Coords)
+	case *MultiPoint:
+		for _, c := range v.Coords {
+			if bbox.Contains(c) {
+				return true
+			}
+		}
+		return false
+	case *LineString:
+		return intersectsCurve(v.Coords, bbox)
+	case *MultiLineString:
+		for _, line := range v.Coords {
+			if intersectsCurve(line, bbox) {
+				return true
+			}
+		}
+		return false
+	case *Polygon:
+		return intersectsSurface(v.Coords, bbox)
+	case *MultiPolygon:
+		for _, surface := range v.Coords {
+			if intersectsSurface(surface, bbox) {
+				return true
+			}
+		}
+		return false
+	case *GeometryCollection:
+		for _, x := range v.Geometries {
+			if Intersects(x, bbox) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func intersectsCurve(seq Curve, bbox BoundingBox) bool {
+	if len(seq) == 1 {
+		return bbox.Contains(seq[0])
+	}
+
+	for i := 1; i < len(seq); i++ {
+		if _, _, ok := clipSegment(seq[i-1], seq[i], bbox); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func intersectsSurface(seq Surface, bbox BoundingBox) bool {
+	for _, ring := range seq {
+		if intersectsCurve(ring, bbox) {
+			return true
+		}
+	}
+
+	// Note: none of rings crosses the box, the box is either fully inside
+	//       of polygon (outside of holes) or outside of it.
+	return seq.contains(bbox.SouthWest())
+}
+
+// clip segment a, b to bounding box using Liang–Barsky algorithm, it
+// returns parameters of entry and exit positions.
+func clipSegment(a, b Coord, bbox BoundingBox) (float64, float64, bool) {
+	sw, ne := bbox.SouthWest(), bbox.NorthEast()
+	dx, dy := b.Lng()-a.Lng(), b.Lat()-a.Lat()
+
+	t0, t1 := 0.0, 1.0
+	for _, pq := range [][2]float64{
+		{-dx, a.Lng() - sw.Lng()},
+		{dx, ne.Lng() - a.Lng()},
+		{-dy, a.Lat() - sw.Lat()},
+		{dy, ne.Lat() - a.Lat()},
+	} {
+		p, q := pq[0], pq[1]
+		switch {
+		case p == 0:
+			if q < 0 {
+				return 0, 0, false
+			}
+		case p < 0:
+			if t := q / p; t > t1 {
+				return 0, 0, false
+			} else if t > t0 {
+				t0 = t
+			}
+		default:
+			if t := q / p; t < t0 {
+				return 0, 0, false
+			} else if t < t1 {
+				t1 = t
+			}
+		}
+	}
+
+	return t0, t1, true
+}
+
+// position of segment a, b at parameter t
+func positionAt(a, b Coord, t float64) Coord {
+	switch t {
+	case 0:
+		return clone(a)
+	case 1:
+		return clone(b)
+	default:
+		return interpolate(a, b, t)
+	}
+}
+
+// clip curve to bounding box, it returns parts of curve inside the box
+func clipCurve(curve Curve, bbox BoundingBox) Surface {
+	seq := Surface{}
+
+	var part Curve
+	flush := func() {
+		if len(part) >= 2 {
+			seq = append(seq, part)
+		}
+		part = nil
+	}
+
+	for i := 0; i+1 < len(curve); i++ {
+		a, b := curve[i], curve[i+1]
+		t0, t1, ok := clipSegment(a, b, bbox)
+		if !ok {
+			flush()
+			continue
+		}
+
+		if part == nil || t0 > 0 {
+			flush()
+			part = Curve{positionAt(a, b, t0)}
+		}
+		part = append(part, positionAt(a, b, t1))
+
+		if t1 < 1 {
+			flush()
+		}
+	}
+	flush()
+
+	return seq
+}
+
+// clip rings of surface to bounding box, it returns nil if the exterior
+// ring is outside of the box. Holes outside of the box are dropped.
+func clipSurface(surface Surface, bbox BoundingBox) Surface {
+	if len(surface) == 0 {
+		return nil
+	}
+
+	sw, ne := bbox.SouthWest(), bbox.NorthEast()
+
+	seq := Surface{}
+	for i, ring := range surface {
+		ring = clipRingAxis(ring, 0, sw.Lng(), false)
+		ring = clipRingAxis(ring, 0, ne.Lng(), true)
+		ring = clipRingAxis(ring, 1, sw.Lat(), false)
+		ring = clipRingAxis(ring, 1, ne.Lat(), true)
+
+		if len(ring) < 4 {
+			if i == 0 {
+				return nil
+			}
+			continue
+		}
+
+		seq = append(seq, transformCurve(ring, clone))
+	}
+
+	return seq
+}
+
+// clipRingAxis clips closed ring against the edge at the axis (0 is
+// longitude, 1 is latitude) using Sutherland–Hodgman algorithm. The part
+// of the ring below the edge is retained if below is set, above otherwise.
+func clipRingAxis(ring Curve, axis int, edge float64, below bool) Curve {
+	inside := func(c Coord) bool {
+		if below {
+			return c[axis] <= edge
+		}
+		return c[axis] >= edge
+	}
+
+	var seq Curve
+	for i := 0; i+1 < len(ring); i++ {
+		a, b := ring[i], ring[i+1]
+		ina, inb := inside(a), inside(b)
+
+		if ina {
+			seq = append(seq, a)
+		}
+
+		if ina != inb && a[axis] != b[axis] {
+			c := interpolate(a, b, (edge-a[axis])/(b[axis]-a[axis]))
+			c[axis] = edge
+			if !(ina && a[axis] == edge) && !(inb && b[axis] == edge) {
+				seq = append(seq, c)
+			}
+		}
+	}
+
+	if len(seq) > 0 {
+		seq = append(seq, clone(seq[0]))
+	}
+
+	return seq
+}
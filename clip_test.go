@@ -0,0 +1,141 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+var tile = geojson.BoundingBox{0.0, 0.0, 10.0, 10.0}
+
+func TestClipPoint(t *testing.T) {
+	inside := &geojson.Point{Coords: geojson.Coord{5.0, 5.0}}
+
+	it.Then(t).Should(
+		it.Equal(geojson.Clip(inside, tile), geojson.Geometry(inside)),
+		it.Equiv(geojson.Clip(&geojson.Point{Coords: geojson.Coord{15.0, 5.0}}, tile), nil),
+		it.Equiv(
+			geojson.Clip(&geojson.MultiPoint{Coords: geojson.Curve{{1.0, 1.0}, {11.0, 1.0}, {10.0, 10.0}}}, tile),
+			geojson.Geometry(&geojson.MultiPoint{Coords: geojson.Curve{{1.0, 1.0}, {10.0, 10.0}}}),
+		),
+		it.Equiv(geojson.Clip(&geojson.MultiPoint{Coords: geojson.Curve{{11.0, 1.0}}}, tile), nil),
+	)
+}
+
+func TestClipLineString(t *testing.T) {
+	it.Then(t).Should(
+		it.Equiv(
+			geojson.Clip(&geojson.LineString{Coords: geojson.Curve{{-5.0, 5.0}, {5.0, 5.0, 10.0}, {5.0, 15.0, 20.0}}}, tile),
+			geojson.Geometry(&geojson.LineString{Coords: geojson.Curve{{0.0, 5.0}, {5.0, 5.0, 10.0}, {5.0, 10.0, 15.0}}}),
+		),
+		it.Equiv(
+			geojson.Clip(&geojson.LineString{Coords: geojson.Curve{{2.0, 5.0}, {2.0, 15.0}, {8.0, 15.0}, {8.0, 5.0}}}, tile),
+			geojson.Geometry(&geojson.MultiLineString{Coords: geojson.Surface{
+				{{2.0, 5.0}, {2.0, 10.0}},
+				{{8.0, 10.0}, {8.0, 5.0}},
+			}}),
+		),
+		it.Equiv(
+			geojson.Clip(&geojson.LineString{Coords: geojson.Curve{{-5.0, -5.0}, {-5.0, 15.0}}}, tile),
+			nil,
+		),
+		it.Equiv(
+			geojson.Clip(&geojson.MultiLineString{Coords: geojson.Surface{
+				{{-5.0, 5.0}, {15.0, 5.0}},
+				{{-5.0, 15.0}, {15.0, 15.0}},
+			}}, tile),
+			geojson.Geometry(&geojson.MultiLineString{Coords: geojson.Surface{{{0.0, 5.0}, {10.0, 5.0}}}}),
+		),
+	)
+}
+
+func TestClipPolygon(t *testing.T) {
+	geo := &geojson.Polygon{Coords: geojson.Surface{
+		{{5.0, 5.0}, {15.0, 5.0}, {15.0, 15.0}, {5.0, 15.0}, {5.0, 5.0}},
+		{{6.0, 6.0}, {6.0, 8.0}, {8.0, 8.0}, {8.0, 6.0}, {6.0, 6.0}},
+		{{12.0, 12.0}, {12.0, 13.0}, {13.0, 13.0}, {13.0, 12.0}, {12.0, 12.0}},
+	}}
+
+	clip := geojson.Clip(geo, tile).(*geojson.Polygon)
+	it.Then(t).Should(
+		it.Equal(len(clip.Coords), 2),
+		it.Equiv(clip.BoundingBox(), geojson.BoundingBox{5.0, 5.0, 10.0, 10.0}),
+		it.Equiv(clip.Coords[0][0], clip.Coords[0][len(clip.Coords[0])-1]),
+		it.Equiv(clip.Coords[1], geo.Coords[1]),
+		it.True(near(clip.Area(), (&geojson.Polygon{Coords: geojson.Surface{
+			{{5.0, 5.0}, {10.0, 5.0}, {10.0, 10.0}, {5.0, 10.0}, {5.0, 5.0}},
+			{{6.0, 6.0}, {6.0, 8.0}, {8.0, 8.0}, {8.0, 6.0}, {6.0, 6.0}},
+		}}).Area(), 1.0)),
+	)
+
+	it.Then(t).Should(
+		it.Equiv(geojson.Clip(&geojson.Polygon{Coords: geojson.Surface{
+			{{20.0, 20.0}, {30.0, 20.0}, {30.0, 30.0}, {20.0, 20.0}},
+		}}, tile), nil),
+		it.Equiv(
+			geojson.Clip(&geojson.MultiPolygon{Coords: geojson.Surfaces{
+				{{{20.0, 20.0}, {30.0, 20.0}, {30.0, 30.0}, {20.0, 20.0}}},
+				{{{1.0, 1.0}, {2.0, 1.0}, {2.0, 2.0}, {1.0, 1.0}}},
+			}}, tile),
+			geojson.Geometry(&geojson.MultiPolygon{Coords: geojson.Surfaces{
+				{{{1.0, 1.0}, {2.0, 1.0}, {2.0, 2.0}, {1.0, 1.0}}},
+			}}),
+		),
+		it.Equiv(
+			geojson.Clip(&geojson.GeometryCollection{Geometries: []geojson.Geometry{
+				&geojson.Point{Coords: geojson.Coord{15.0, 5.0}},
+				&geojson.Point{Coords: geojson.Coord{5.0, 5.0}},
+			}}, tile),
+			geojson.Geometry(&geojson.GeometryCollection{Geometries: []geojson.Geometry{
+				&geojson.Point{Coords: geojson.Coord{5.0, 5.0}},
+			}}),
+		),
+		it.Equiv(geojson.Clip(geo, nil), nil),
+	)
+}
+
+func TestIntersects(t *testing.T) {
+	square := func(lo, hi float64) geojson.Curve {
+		return geojson.Curve{{lo, lo}, {hi, lo}, {hi, hi}, {lo, hi}, {lo, lo}}
+	}
+
+	it.Then(t).Should(
+		// points
+		it.True(geojson.Intersects(&geojson.Point{Coords: geojson.Coord{5.0, 5.0}}, tile)),
+		it.True(geojson.Intersects(&geojson.Point{Coords: geojson.Coord{10.0, 10.0}}, tile)),
+		it.True(!geojson.Intersects(&geojson.Point{Coords: geojson.Coord{15.0, 5.0}}, tile)),
+		it.True(geojson.Intersects(&geojson.MultiPoint{Coords: geojson.Curve{{15.0, 5.0}, {5.0, 5.0}}}, tile)),
+
+		// line crosses the box without vertices inside
+		it.True(geojson.Intersects(&geojson.LineString{Coords: geojson.Curve{{-5.0, 5.0}, {15.0, 5.0}}}, tile)),
+		// line passes by the corner, its bounding box overlaps the tile
+		it.True(!geojson.Intersects(&geojson.LineString{Coords: geojson.Curve{{-5.0, 8.0}, {2.0, 15.0}}}, tile)),
+		it.True(geojson.Intersects(&geojson.MultiLineString{Coords: geojson.Surface{{{20.0, 20.0}, {30.0, 30.0}}, {{5.0, -5.0}, {5.0, 15.0}}}}, tile)),
+
+		// polygon overlaps, contains the box, is inside of the box
+		it.True(geojson.Intersects(&geojson.Polygon{Coords: geojson.Surface{square(5.0, 15.0)}}, tile)),
+		it.True(geojson.Intersects(&geojson.Polygon{Coords: geojson.Surface{square(-10.0, 20.0)}}, tile)),
+		it.True(geojson.Intersects(&geojson.Polygon{Coords: geojson.Surface{square(2.0, 8.0)}}, tile)),
+		// the box is inside of the hole
+		it.True(!geojson.Intersects(&geojson.Polygon{Coords: geojson.Surface{square(-10.0, 20.0), square(-5.0, 15.0)}}, tile)),
+		it.True(!geojson.Intersects(&geojson.Polygon{Coords: geojson.Surface{square(20.0, 30.0)}}, tile)),
+		it.True(geojson.Intersects(&geojson.MultiPolygon{Coords: geojson.Surfaces{{square(20.0, 30.0)}, {square(-10.0, 20.0)}}}, tile)),
+
+		it.True(geojson.Intersects(&geojson.GeometryCollection{Geometries: []geojson.Geometry{
+			&geojson.Point{Coords: geojson.Coord{15.0, 5.0}},
+			&geojson.Point{Coords: geojson.Coord{5.0, 5.0}},
+		}}, tile)),
+		it.True(!geojson.Intersects(nil, tile)),
+		it.True(!geojson.Intersects(&geojson.Point{Coords: geojson.Coord{5.0, 5.0}}, nil)),
+		it.True(!geojson.Intersects(&geojson.Point{}, tile)),
+	)
+}
@@ -10,6 +10,7 @@ package geojson
 
 import (
 	"encoding/json"
+	"iter"
 )
 
 const TYPE_FEATURE_COLLECTION = "FeatureCollection"
@@ -31,24 +32,71 @@ const TYPE_FEATURE_COLLECTION = "FeatureCollection"
 //	  geojson.Collection[MyType]
 //	  Name string `json:"name,omitempty"`
 //	}
+//
+// The bounding box decoded from GeoJSON is retained at BBox, it takes
+// precedence over the box computed from the features. The coordinate
+// reference system of legacy GeoJSON is retained at CRS.
 type Collection[T interface{ BoundingBox() BoundingBox }] struct {
-	Features []T `json:"-"`
+	BBox     BoundingBox `json:"-"`
+	CRS      *CRS        `json:"-"`
+	Features []T         `json:"-"`
 }
 
-// BoundingBox of the features collection
+// BoundingBox of the features collection, it is the union of boxes of
+// located features or nil if none of features is located.
 func (c Collection[T]) BoundingBox() BoundingBox {
-	if len(c.Features) == 0 {
-		return nil
+	if len(c.BBox) != 0 {
+		return c.BBox
 	}
 
-	bbox := c.Features[0].BoundingBox()
-	for i := 1; i < len(c.Features); i++ {
-		bbox.Join(c.Features[i].BoundingBox())
+	// Note: Join skips unlocated features and copies the box of the first
+	//       located one, the stored box of the feature is not mutated.
+	var bbox BoundingBox
+	for _, x := range c.Features {
+		bbox = bbox.Join(x.BoundingBox())
 	}
 
 	return bbox
 }
 
+// MarshalJSON encodes collection to GeoJSON, it is used when the collection
+// has no foreign members, otherwise see EncodeGeoJSON. The codec is defined
+// on pointer, it is not promoted to values of types that embed collection
+// (e.g. tStruct of EncodeGeoJSON). The embedding type must define its own
+// MarshalJSON, the pointer to it is encoded without foreign members
+// otherwise. Use FeatureCollection to encode collection by value.
+func (c *Collection[T]) MarshalJSON() ([]byte, error) {
+	return c.EncodeGeoJSON(nil)
+}
+
+// UnmarshalJSON decodes collection from GeoJSON, it is used when the collection
+// has no foreign members, otherwise see DecodeGeoJSON.
+func (c *Collection[T]) UnmarshalJSON(b []byte) error {
+	return c.DecodeGeoJSON(b, nil)
+}
+
+// FeatureCollection is collection of features with default GeoJSON codec,
+// it is encoded by value, e.g. as a member of application type. It is not
+// intended for embedding, use Collection for the collection with foreign
+// members.
+//
+//	type Layer struct {
+//	  Cities geojson.FeatureCollection[City] `json:"cities"`
+//	}
+type FeatureCollection[T interface{ BoundingBox() BoundingBox }] struct {
+	Collection[T]
+}
+
+// MarshalJSON encodes collection to GeoJSON
+func (c FeatureCollection[T]) MarshalJSON() ([]byte, error) {
+	return c.Collection.EncodeGeoJSON(nil)
+}
+
+// UnmarshalJSON decodes collection from GeoJSON
+func (c *FeatureCollection[T]) UnmarshalJSON(b []byte) error {
+	return c.Collection.DecodeGeoJSON(b, nil)
+}
+
 // EncodeGeoJSON is a helper function to implement GeoJSON codec
 //
 //	func (x MyCollection) MarshalJSON() ([]byte, error) {
@@ -56,7 +104,40 @@ func (c Collection[T]) BoundingBox() BoundingBox {
 //		return x.Features.EncodeGeoJSON(tStruct(x))
 //	}
 func (c Collection[T]) EncodeGeoJSON(props any) ([]byte, error) {
-	properties, err := json.Marshal(props)
+	return c.EncodeGeoJSONWith(props)
+}
+
+// EncodeGeoJSONWith is a helper function to implement GeoJSON codec,
+// it is configurable version of EncodeGeoJSON. Options are applied to the
+// collection and each feature that embeds Feature, the feature's own codec
+// defines its properties and foreign members. Features of other types are
+// subject to the bounding box policy only.
+//
+//	func (x MyCollection) MarshalJSON() ([]byte, error) {
+//		type tStruct MyCollection
+//		return x.Features.EncodeGeoJSONWith(tStruct(x), geojson.WithBBox(geojson.BBoxNever))
+//	}
+func (c Collection[T]) EncodeGeoJSONWith(props any, opts ...EncodeOption) ([]byte, error) {
+	enc := newEncoder(opts)
+
+	var properties json.RawMessage
+	if props != nil {
+		b, err := json.Marshal(props)
+		if err != nil {
+			return nil, err
+		}
+		properties = b
+	}
+
+	var bbox BoundingBox
+	if enc.bbox != BBoxNever {
+		bbox = enc.boundingBox(c.BoundingBox())
+		if err := validateFiniteBBox(bbox); err != nil {
+			return nil, err
+		}
+	}
+
+	features, err := encodeFeatures(enc, c.Features)
 	if err != nil {
 		return nil, err
 	}
@@ -64,16 +145,103 @@ func (c Collection[T]) EncodeGeoJSON(props any) ([]byte, error) {
 	val := struct {
 		Type       string          `json:"type"`
 		BBox       BoundingBox     `json:"bbox,omitempty"`
-		Features   []T             `json:"features,omitempty"`
+		Features   any             `json:"features"`
 		Properties json.RawMessage `json:"properties,omitempty"`
+		CRS        *CRS            `json:"crs,omitempty"`
 	}{
 		Type:       TYPE_FEATURE_COLLECTION,
-		BBox:       c.BoundingBox(),
-		Features:   c.Features,
+		BBox:       bbox,
+		Features:   features,
 		Properties: properties,
+		CRS:        c.CRS,
 	}
 
-	return json.Marshal(val)
+	b, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return enc.output(b, roleCollection), nil
+}
+
+// encodeFeatures applies encoder options to features encoded by its own
+// codec. Members of the embedded Feature are encoded with options, the
+// codec of application defines properties and foreign members. Features
+// of other types are subject to bounding box policy only.
+func encodeFeatures[T interface{ BoundingBox() BoundingBox }](enc *encoder, features []T) (any, error) {
+	if features == nil {
+		return []T{}, nil
+	}
+
+	if enc.isDefault() || len(features) == 0 {
+		return features, nil
+	}
+
+	seq := make([]json.RawMessage, len(features))
+	for i, x := range features {
+		b, err := json.Marshal(x)
+		if err != nil {
+			return nil, err
+		}
+
+		var bag map[string]json.RawMessage
+		if err := json.Unmarshal(b, &bag); err != nil {
+			return nil, err
+		}
+
+		if f, ok := any(&x).(interface{ feature() *Feature }); ok {
+			if err := encodeFeatureMembers(enc, f.feature(), bag); err != nil {
+				return nil, err
+			}
+		} else if err := encodeBBoxMember(enc, x.BoundingBox(), bag); err != nil {
+			return nil, err
+		}
+
+		if seq[i], err = encodeMembers(bag); err != nil {
+			return nil, err
+		}
+	}
+
+	return seq, nil
+}
+
+// encodeFeatureMembers replaces id, bbox and geometry members of the
+// feature with ones encoded using options.
+func encodeFeatureMembers(enc *encoder, fea *Feature, bag map[string]json.RawMessage) error {
+	b, err := fea.encode(enc, nil)
+	if err != nil {
+		return err
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(b, &members); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"id", "bbox", "geometry"} {
+		if val, has := members[key]; has {
+			bag[key] = val
+		} else {
+			delete(bag, key)
+		}
+	}
+
+	return nil
+}
+
+// encodeBBoxMember applies bounding box policy to the encoded feature
+func encodeBBoxMember(enc *encoder, bbox BoundingBox, bag map[string]json.RawMessage) (err error) {
+	switch enc.bbox {
+	case BBoxNever:
+		delete(bag, "bbox")
+	case BBoxAlways:
+		if bbox := enc.boundingBox(bbox); len(bbox) >= 4 {
+			if _, has := bag["bbox"]; !has {
+				bag["bbox"], err = json.Marshal(bbox)
+			}
+		}
+	}
+	return err
 }
 
 // DecodeGeoJSON is a helper function to implement GeoJSON codec
@@ -88,6 +256,7 @@ func (c *Collection[T]) DecodeGeoJSON(bytes []byte, props interface{}) error {
 		BBox       BoundingBox     `json:"bbox,omitempty"`
 		Features   json.RawMessage `json:"features,omitempty"`
 		Properties json.RawMessage `json:"properties,omitempty"`
+		CRS        *CRS            `json:"crs,omitempty"`
 	}{}
 
 	if err := json.Unmarshal(bytes, &val); err != nil {
@@ -98,13 +267,16 @@ func (c *Collection[T]) DecodeGeoJSON(bytes []byte, props interface{}) error {
 		return ErrUnsupportedType
 	}
 
+	c.BBox = val.BBox
+	c.CRS = val.CRS
+
 	if val.Features != nil {
 		if err := json.Unmarshal(val.Features, &c.Features); err != nil {
 			return err
 		}
 	}
 
-	if val.Properties != nil {
+	if val.Properties != nil && props != nil {
 		if err := json.Unmarshal(val.Properties, &props); err != nil {
 			return err
 		}
@@ -112,3 +284,196 @@ func (c *Collection[T]) DecodeGeoJSON(bytes []byte, props interface{}) error {
 
 	return nil
 }
+
+// Filter returns new collection of features that satisfy the predicate.
+// The bounding box of new collection is computed on demand.
+func (c Collection[T]) Filter(pred func(T) bool) Collection[T] {
+	seq := make([]T, 0, len(c.Features))
+	for _, x := range c.Features {
+		if pred(x) {
+			seq = append(seq, x)
+		}
+	}
+	return Collection[T]{CRS: c.CRS, Features: seq}
+}
+
+// Map returns new collection of transformed features.
+// The bounding box of new collection is computed on demand.
+func (c Collection[T]) Map(f func(T) T) Collection[T] {
+	seq := make([]T, len(c.Features))
+	for i, x := range c.Features {
+		seq[i] = f(x)
+	}
+	return Collection[T]{CRS: c.CRS, Features: seq}
+}
+
+// Find returns the first feature that satisfies the predicate
+func (c Collection[T]) Find(pred func(T) bool) (T, bool) {
+	for _, x := range c.Features {
+		if pred(x) {
+			return x, true
+		}
+	}
+
+	var none T
+	return none, false
+}
+
+// Within returns new collection of features whose bounding box intersects
+// the given one. It is linear scan over features, see BuildIndex for
+// repeated queries over large collections.
+func (c Collection[T]) Within(bbox BoundingBox) Collection[T] {
+	return c.Filter(func(x T) bool { return bbox.Intersects(x.BoundingBox()) })
+}
+
+// All returns sequence of features of the collection, it ranges over
+// the collection in order without copying it.
+//
+//	for fea := range c.All() {
+//	  ...
+//	}
+func (c Collection[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, x := range c.Features {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk splits the collection into collections of at most size features,
+// preserving the order of features. The bounding box of each chunk is
+// computed on demand from its features, the reference system is retained.
+// Chunks share features with the collection, appending to a chunk does not
+// affect others. The size less than 1 makes a single chunk. It returns
+// nil for empty collection.
+//
+// Properties of the collection are not part of Collection type, the caller
+// wraps chunks into application type if they are required at each page.
+func (c Collection[T]) Chunk(size int) []Collection[T] {
+	if len(c.Features) == 0 {
+		return nil
+	}
+
+	if size < 1 {
+		size = len(c.Features)
+	}
+
+	seq := make([]Collection[T], 0, (len(c.Features)+size-1)/size)
+	for i := 0; i < len(c.Features); i += size {
+		j := min(i+size, len(c.Features))
+		seq = append(seq, Collection[T]{CRS: c.CRS, Features: c.Features[i:j:j]})
+	}
+	return seq
+}
+
+// Len returns number of features in the collection
+func (c Collection[T]) Len() int {
+	return len(c.Features)
+}
+
+// Dimensions returns the minimum and maximum number of elements of positions
+// across geometries of features, e.g. (2, 3) for collection mixing 2D and 3D
+// positions. It returns (0, 0) if collection has no positions. Only features
+// that embed Feature are inspected.
+func (c Collection[T]) Dimensions() (lo, hi int) {
+	for i := range c.Features {
+		f, ok := any(&c.Features[i]).(interface{ feature() *Feature })
+		if !ok {
+			continue
+		}
+
+		f.feature().FMap(func(x Coord) {
+			switch n := len(x); {
+			case n == 0:
+			case lo == 0:
+				lo, hi = n, n
+			case n < lo:
+				lo = n
+			case n > hi:
+				hi = n
+			}
+		})
+	}
+
+	return lo, hi
+}
+
+// ConsistentDimensions checks that all positions of collection have the same
+// number of elements, see Dimensions.
+func (c Collection[T]) ConsistentDimensions() bool {
+	lo, hi := c.Dimensions()
+	return lo == hi
+}
+
+// Append features to the collection. The stored bounding box is reset,
+// the aggregate one is recomputed on demand by BoundingBox.
+func (c *Collection[T]) Append(features ...T) {
+	c.Features = append(c.Features, features...)
+	c.BBox = nil
+}
+
+// Concat returns new collection of features from both collections.
+// The bounding box of new collection is recomputed on demand.
+func (c Collection[T]) Concat(other Collection[T]) Collection[T] {
+	seq := make([]T, 0, len(c.Features)+len(other.Features))
+	seq = append(seq, c.Features...)
+	seq = append(seq, other.Features...)
+	return Collection[T]{CRS: c.CRS, Features: seq}
+}
+
+// Dissolve groups features by the key and merges polygons of each group
+// using UnionPolygons, e.g. regions of the country from its provinces. The
+// group is represented by its first feature, its geometry is replaced by
+// the union and the stored bounding box is reset. Groups follow the order
+// of their first features. Geometries other than Polygon and MultiPolygon
+// are not merged, the group without polygons keeps geometry of its first
+// feature. Polygons of the group are combined into MultiPolygon as-is if
+// the union fails, e.g. polygon is invalid.
+func (c Collection[T]) Dissolve(key func(T) string) Collection[T] {
+	keys := []string{}
+	groups := map[string][]int{}
+	for i, x := range c.Features {
+		k := key(x)
+		if _, has := groups[k]; !has {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], i)
+	}
+
+	seq := make([]T, 0, len(keys))
+	for _, k := range keys {
+		x := c.Features[groups[k][0]]
+		f, ok := any(&x).(interface{ feature() *Feature })
+		if !ok {
+			seq = append(seq, x)
+			continue
+		}
+
+		polys := []*Polygon{}
+		for _, i := range groups[k] {
+			if g, ok := any(&c.Features[i]).(interface{ feature() *Feature }); ok {
+				polys = append(polys, polygonsOf(g.feature().Geometry)...)
+			}
+		}
+
+		if len(polys) > 0 {
+			geo, err := UnionPolygons(polys...)
+			if err != nil {
+				seq := make(Surfaces, len(polys))
+				for i, poly := range polys {
+					seq[i] = poly.Coords
+				}
+				geo = &MultiPolygon{Coords: seq}
+			}
+
+			fea := f.feature()
+			fea.SetGeometry(geo)
+			fea.BBox = nil
+		}
+		seq = append(seq, x)
+	}
+
+	return Collection[T]{CRS: c.CRS, Features: seq}
+}
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/fogfish/curie/v2"
 	"github.com/fogfish/geojson"
 	"github.com/fogfish/it/v2"
 )
@@ -68,3 +69,373 @@ func TestCollection(t *testing.T) {
 		it.Equal(c.Name, "Cities"),
 	)
 }
+
+func TestCollectionBBox(t *testing.T) {
+	seq := GeoJsonCities{
+		Collection: geojson.Collection[GeoJsonCity]{
+			BBox: geojson.BoundingBox{-180.0, -90.0, 180.0, 90.0},
+			Features: []GeoJsonCity{
+				{Feature: geojson.NewPoint("city:hel", geojson.Coord{101.0, 1.0})},
+			},
+		},
+	}
+
+	bin, err := json.Marshal(seq)
+	it.Then(t).Should(it.Nil(err))
+
+	var c GeoJsonCities
+	err = json.Unmarshal(bin, &c)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(c.BBox, seq.BBox),
+		it.Equiv(c.BoundingBox(), geojson.BoundingBox{-180.0, -90.0, 180.0, 90.0}),
+	)
+}
+
+func TestCollectionCodec(t *testing.T) {
+	seq := geojson.Collection[GeoJsonCity]{
+		Features: []GeoJsonCity{
+			{
+				Feature: geojson.NewPoint("city:hel", geojson.Coord{101.0, 1.0}),
+				City:    City{Name: "Helsinki"},
+			},
+			{
+				Feature: geojson.NewPoint("city:sto", geojson.Coord{102.0, 2.0}),
+				City:    City{Name: "Stockholm"},
+			},
+		},
+	}
+
+	bin, err := json.Marshal(&seq)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(bin)).Contain(`"type":"FeatureCollection"`),
+	)
+
+	var c geojson.Collection[GeoJsonCity]
+	err = json.Unmarshal(bin, &c)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(c.Features, seq.Features),
+	)
+}
+
+func TestCollectionCodecEmpty(t *testing.T) {
+	var seq geojson.Collection[GeoJsonCity]
+
+	for _, opts := range [][]geojson.EncodeOption{nil, {geojson.WithBBox(geojson.BBoxNever)}} {
+		bin, err := seq.EncodeGeoJSONWith(nil, opts...)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(string(bin), `{"type":"FeatureCollection","features":[]}`),
+		)
+	}
+}
+
+func TestFeatureCollectionCodec(t *testing.T) {
+	type Scene struct {
+		Cities geojson.FeatureCollection[GeoJsonCity] `json:"cities"`
+	}
+
+	seq := geojson.FeatureCollection[GeoJsonCity]{
+		Collection: geojson.Collection[GeoJsonCity]{
+			Features: []GeoJsonCity{
+				{
+					Feature: geojson.NewPoint("city:hel", geojson.Coord{101.0, 1.0}),
+					City:    City{Name: "Helsinki"},
+				},
+			},
+		},
+	}
+
+	bin, err := json.Marshal(seq)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(bin)).Contain(`"type":"FeatureCollection"`),
+	)
+
+	bin, err = json.Marshal(Scene{Cities: seq})
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(bin)).Contain(`"cities":{"type":"FeatureCollection"`),
+	)
+
+	var scene Scene
+	err = json.Unmarshal(bin, &scene)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(scene.Cities.Features, seq.Features),
+	)
+}
+
+func testCities() geojson.Collection[GeoJsonCity] {
+	return geojson.Collection[GeoJsonCity]{
+		BBox: geojson.BoundingBox{-180.0, -90.0, 180.0, 90.0},
+		Features: []GeoJsonCity{
+			{
+				Feature: geojson.NewPoint("city:spb", geojson.Coord{100.0, 0.0}),
+				City:    City{Name: "Saint-Petersburg"},
+			},
+			{
+				Feature: geojson.NewPoint("city:hel", geojson.Coord{101.0, 1.0}),
+				City:    City{Name: "Helsinki"},
+			},
+			{
+				Feature: geojson.NewPoint("city:sto", geojson.Coord{102.0, 2.0}),
+				City:    City{Name: "Stockholm"},
+			},
+		},
+	}
+}
+
+func TestCollectionFilter(t *testing.T) {
+	seq := testCities().Filter(func(x GeoJsonCity) bool { return x.Name != "Helsinki" })
+
+	it.Then(t).Should(
+		it.Equal(len(seq.Features), 2),
+		it.Equal(seq.Features[0].Name, "Saint-Petersburg"),
+		it.Equal(seq.Features[1].Name, "Stockholm"),
+		it.Equiv(seq.BoundingBox(), geojson.BoundingBox{100.0, 0.0, 102.0, 2.0}),
+	)
+}
+
+func TestCollectionMap(t *testing.T) {
+	cities := testCities()
+	seq := cities.Map(func(x GeoJsonCity) GeoJsonCity {
+		x.Name = x.Name + "!"
+		return x
+	})
+
+	it.Then(t).Should(
+		it.Equal(len(seq.Features), 3),
+		it.Equal(seq.Features[1].Name, "Helsinki!"),
+		it.Equal(cities.Features[1].Name, "Helsinki"),
+	)
+}
+
+func TestCollectionFind(t *testing.T) {
+	cities := testCities()
+	hel, hasHel := cities.Find(func(x GeoJsonCity) bool { return x.ID == "city:hel" })
+	_, hasOsl := cities.Find(func(x GeoJsonCity) bool { return x.ID == "city:osl" })
+
+	it.Then(t).Should(
+		it.True(hasHel),
+		it.Equal(hel.Name, "Helsinki"),
+		it.True(!hasOsl),
+	)
+}
+
+func TestCollectionAppend(t *testing.T) {
+	seq := geojson.Collection[GeoJsonCity]{
+		BBox: geojson.BoundingBox{100.0, 0.0, 101.0, 1.0},
+	}
+	seq.Append(testCities().Features...)
+
+	it.Then(t).Should(
+		it.Equal(seq.Len(), 3),
+		it.Equiv(seq.BBox, nil),
+		it.Equiv(seq.BoundingBox(), geojson.BoundingBox{100.0, 0.0, 102.0, 2.0}),
+	)
+}
+
+func TestCollectionConcat(t *testing.T) {
+	a := testCities()
+	b := testCities().Filter(func(x GeoJsonCity) bool { return x.Name == "Helsinki" })
+	seq := a.Concat(b)
+
+	it.Then(t).Should(
+		it.Equal(seq.Len(), 4),
+		it.Equal(a.Len(), 3),
+		it.Equal(seq.Features[3].Name, "Helsinki"),
+	)
+}
+
+func TestCollectionBoundingBoxUnlocated(t *testing.T) {
+	seq := geojson.Collection[geojson.Feature]{
+		Features: []geojson.Feature{
+			{ID: "city:unknown"},
+			geojson.NewPoint("city:hel", geojson.Coord{101.0, 1.0}),
+			{ID: "city:unknown"},
+			geojson.NewPoint("city:sto", geojson.Coord{102.0, 2.0}),
+		},
+	}
+
+	it.Then(t).Should(
+		it.Equiv(seq.BoundingBox(), geojson.BoundingBox{101.0, 1.0, 102.0, 2.0}),
+		it.Equiv(
+			geojson.Collection[geojson.Feature]{Features: []geojson.Feature{{}, {}}}.BoundingBox(),
+			nil,
+		),
+	)
+
+	// stored box of the feature is not mutated by aggregation
+	bbox := geojson.BoundingBox{100.0, 0.0, 100.5, 0.5}
+	seq.Features[0].BBox = bbox
+	it.Then(t).Should(
+		it.Equiv(seq.BoundingBox(), geojson.BoundingBox{100.0, 0.0, 102.0, 2.0}),
+		it.Equiv(bbox, geojson.BoundingBox{100.0, 0.0, 100.5, 0.5}),
+	)
+}
+
+func TestCollectionDimensions(t *testing.T) {
+	cities := testCities()
+	lo, hi := cities.Dimensions()
+	it.Then(t).Should(
+		it.Equal(lo, 2),
+		it.Equal(hi, 2),
+		it.True(cities.ConsistentDimensions()),
+	)
+
+	cities.Append(
+		GeoJsonCity{Feature: geojson.NewLineString("city:lin", geojson.Curve{{100.0, 0.0}, {101.0, 1.0, 10.0}})},
+		GeoJsonCity{Feature: geojson.Feature{ID: "city:nil"}},
+	)
+	lo, hi = cities.Dimensions()
+	it.Then(t).Should(
+		it.Equal(lo, 2),
+		it.Equal(hi, 3),
+		it.True(!cities.ConsistentDimensions()),
+	)
+
+	var empty geojson.Collection[GeoJsonCity]
+	lo, hi = empty.Dimensions()
+	it.Then(t).Should(
+		it.Equal(lo, 0),
+		it.Equal(hi, 0),
+		it.True(empty.ConsistentDimensions()),
+	)
+}
+
+func TestCollectionAll(t *testing.T) {
+	c := testCities()
+
+	seq := []string{}
+	for fea := range c.All() {
+		seq = append(seq, fea.Name)
+	}
+
+	head := []string{}
+	for fea := range c.All() {
+		head = append(head, fea.Name)
+		break
+	}
+
+	it.Then(t).Should(
+		it.Seq(seq).Equal("Saint-Petersburg", "Helsinki", "Stockholm"),
+		it.Seq(head).Equal("Saint-Petersburg"),
+	)
+}
+
+func TestCollectionChunk(t *testing.T) {
+	c := testCities()
+	seq := c.Chunk(2)
+
+	it.Then(t).Should(
+		it.Equal(len(seq), 2),
+		it.Equal(seq[0].Len(), 2),
+		it.Equal(seq[1].Len(), 1),
+		it.Equal(seq[0].Features[1].Name, "Helsinki"),
+		it.Equal(seq[1].Features[0].Name, "Stockholm"),
+		it.Equiv(seq[0].BoundingBox(), geojson.BoundingBox{100.0, 0.0, 101.0, 1.0}),
+		it.Equiv(seq[1].BoundingBox(), geojson.BoundingBox{102.0, 2.0, 102.0, 2.0}),
+		it.Equal(len(c.Chunk(0)), 1),
+		it.Equal(len(c.Chunk(3)), 1),
+		it.Equal(len(c.Chunk(1)), 3),
+		it.True(geojson.Collection[GeoJsonCity]{}.Chunk(2) == nil),
+	)
+
+	seq[0].Append(GeoJsonCity{City: City{Name: "Oslo"}})
+	it.Then(t).Should(
+		it.Equal(c.Features[2].Name, "Stockholm"),
+		it.Equal(seq[1].Features[0].Name, "Stockholm"),
+	)
+}
+
+func TestCollectionDissolve(t *testing.T) {
+	province := func(id string, country string, poly *geojson.Polygon) GeoJsonCity {
+		fea := geojson.NewPolygon(curie.IRI(id), poly.Coords)
+		fea.BBox = poly.BoundingBox()
+		return GeoJsonCity{Feature: fea, City: City{Name: country}}
+	}
+
+	c := geojson.Collection[GeoJsonCity]{
+		Features: []GeoJsonCity{
+			province("fi:uusimaa", "Finland", square(0, 0, 1, 1)),
+			province("se:skane", "Sweden", square(5, 5, 6, 6)),
+			province("fi:lappi", "Finland", square(1, 0, 2, 1)),
+			{Feature: geojson.NewPoint("city:hel", geojson.Coord{0.5, 0.5}), City: City{Name: "Finland"}},
+			{Feature: geojson.NewPoint("city:osl", geojson.Coord{10.0, 10.0}), City: City{Name: "Norway"}},
+		},
+	}
+
+	d := c.Dissolve(func(x GeoJsonCity) string { return x.Name })
+	fi, ok := d.Features[0].Geometry.(*geojson.Polygon)
+
+	it.Then(t).Should(
+		it.Equal(d.Len(), 3),
+		it.Equal(d.Features[0].ID, "fi:uusimaa"),
+		it.Equal(d.Features[1].ID, "se:skane"),
+		it.Equal(d.Features[2].ID, "city:osl"),
+		it.True(ok),
+		it.Equal(planarArea(fi), 2.0),
+		it.True(d.Features[0].BBox == nil),
+		it.Equiv(d.Features[0].BoundingBox(), geojson.BoundingBox{0.0, 0.0, 2.0, 1.0}),
+		it.Equal(planarArea(d.Features[1].Geometry), 1.0),
+		it.Equiv(d.Features[2].Geometry, c.Features[4].Geometry),
+		// the collection is not modified
+		it.Equal(planarArea(c.Features[0].Geometry), 1.0),
+		it.Equiv(c.Features[0].BBox, geojson.BoundingBox{0.0, 0.0, 1.0, 1.0}),
+	)
+}
+
+type Layer struct {
+	Name  string `json:"name"`
+	Title string
+}
+
+type Legend struct {
+	Title string
+}
+
+type GeoJsonLayer struct {
+	geojson.Collection[GeoJsonCity]
+	Layer
+	Legend
+	Zoom  int    `json:"zoom"`
+	Alpha string `json:"alpha"`
+}
+
+func (x GeoJsonLayer) MarshalJSON() ([]byte, error) {
+	type tStruct GeoJsonLayer
+	return x.Collection.EncodeGeoJSON(tStruct(x))
+}
+
+func TestCollectionForeignMembers(t *testing.T) {
+	seq := GeoJsonLayer{
+		Collection: geojson.Collection[GeoJsonCity]{
+			Features: []GeoJsonCity{
+				{
+					Feature: geojson.NewPoint("city:hel", geojson.Coord{101.0, 1.0}),
+					City:    City{Name: "Helsinki"},
+				},
+			},
+		},
+		Layer:  Layer{Name: "cities", Title: "Layer"},
+		Legend: Legend{Title: "Legend"},
+		Zoom:   5,
+		Alpha:  "a",
+	}
+
+	// Note: ambiguous title of embedded types is dropped by encoding/json,
+	//       members follow the order of fields.
+	for _, v := range []any{seq, &seq} {
+		bin, err := json.Marshal(v)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.String(string(bin)).Contain(`"properties":{"name":"cities","zoom":5,"alpha":"a"}`),
+		)
+	}
+}
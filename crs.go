@@ -0,0 +1,33 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+// CRS is a coordinate reference system member of legacy GeoJSON (pre
+// RFC 7946). It is either named or linked CRS:
+//
+//	{"type": "name", "properties": {"name": "urn:ogc:def:crs:EPSG::3857"}}
+//	{"type": "link", "properties": {"href": "http://example.com/crs/42", "type": "proj4"}}
+//
+// RFC 7946 assumes WGS84, the member is emitted only if it is explicitly set.
+type CRS struct {
+	Type       string        `json:"type"`
+	Properties CRSProperties `json:"properties"`
+}
+
+// CRSProperties of the named or linked CRS
+type CRSProperties struct {
+	Name string `json:"name,omitempty"`
+	Href string `json:"href,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// NewCRS creates named CRS, e.g. "urn:ogc:def:crs:EPSG::3857"
+func NewCRS(name string) *CRS {
+	return &CRS{Type: "name", Properties: CRSProperties{Name: name}}
+}
@@ -0,0 +1,73 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+const featureWithCRS = `{"type":"Feature","geometry":{"type":"Point","coordinates":[2776348.3,8437752.1]},"properties":{"name":"Helsinki"},"crs":{"type":"name","properties":{"name":"urn:ogc:def:crs:EPSG::3857"}}}`
+
+func TestFeatureCRS(t *testing.T) {
+	var city GeoJsonCity
+	err := json.Unmarshal([]byte(featureWithCRS), &city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(city.CRS, geojson.NewCRS("urn:ogc:def:crs:EPSG::3857")),
+		it.Equal(len(city.Foreign), 0),
+	)
+
+	data, err := json.Marshal(city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(string(data), featureWithCRS),
+	)
+
+	city.CRS = nil
+	data, err = json.Marshal(city)
+	it.Then(t).Should(
+		it.Nil(err),
+	).ShouldNot(
+		it.String(string(data)).Contain(`"crs"`),
+	)
+}
+
+func TestCollectionCRS(t *testing.T) {
+	seq := testCities()
+	seq.CRS = &geojson.CRS{
+		Type:       "link",
+		Properties: geojson.CRSProperties{Href: "http://example.com/crs/42", Type: "proj4"},
+	}
+
+	data, err := json.Marshal(&seq)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"crs":{"type":"link","properties":{"href":"http://example.com/crs/42","type":"proj4"}}`),
+	)
+
+	var c geojson.Collection[GeoJsonCity]
+	it.Then(t).Should(
+		it.Nil(json.Unmarshal(data, &c)),
+		it.Equiv(c.CRS, seq.CRS),
+		it.Equiv(c.Filter(func(GeoJsonCity) bool { return true }).CRS, seq.CRS),
+	)
+
+	cities := testCities()
+	data, err = json.Marshal(&cities)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"type":"FeatureCollection"`),
+	).ShouldNot(
+		it.String(string(data)).Contain(`"crs"`),
+	)
+}
@@ -0,0 +1,125 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import "strconv"
+
+// decodeCoord is a fast path decoder of [lng, lat] and [lng, lat, alt]
+// positions. It returns false on anything unexpected so that caller
+// falls back to encoding/json, which reports errors for malformed input.
+func decodeCoord(b []byte) (Coord, bool) {
+	var (
+		seq [3]float64
+		n   int
+	)
+
+	i := skipSpace(b, 0)
+	if i == len(b) || b[i] != '[' {
+		return nil, false
+	}
+
+	for {
+		i = skipSpace(b, i+1)
+		if n == len(seq) {
+			return nil, false
+		}
+
+		j := scanNumber(b, i)
+		if j == i {
+			return nil, false
+		}
+
+		v, err := strconv.ParseFloat(string(b[i:j]), 64)
+		if err != nil {
+			return nil, false
+		}
+		seq[n] = v
+		n++
+
+		i = skipSpace(b, j)
+		if i == len(b) {
+			return nil, false
+		}
+
+		if b[i] == ']' {
+			break
+		}
+
+		if b[i] != ',' {
+			return nil, false
+		}
+	}
+
+	if n < 2 || skipSpace(b, i+1) != len(b) {
+		return nil, false
+	}
+
+	coord := make(Coord, n)
+	copy(coord, seq[:n])
+	return coord, true
+}
+
+func skipSpace(b []byte, i int) int {
+	for i < len(b) {
+		switch b[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// scanNumber returns end of JSON number started at i, it returns i if
+// the number is not valid according to JSON grammar.
+func scanNumber(b []byte, i int) int {
+	digits := func(j int) int {
+		for j < len(b) && b[j] >= '0' && b[j] <= '9' {
+			j++
+		}
+		return j
+	}
+
+	j := i
+	if j < len(b) && b[j] == '-' {
+		j++
+	}
+
+	switch {
+	case j < len(b) && b[j] == '0':
+		j++
+	case j < len(b) && b[j] >= '1' && b[j] <= '9':
+		j = digits(j)
+	default:
+		return i
+	}
+
+	if j < len(b) && b[j] == '.' {
+		k := digits(j + 1)
+		if k == j+1 {
+			return i
+		}
+		j = k
+	}
+
+	if j < len(b) && (b[j] == 'e' || b[j] == 'E') {
+		j++
+		if j < len(b) && (b[j] == '+' || b[j] == '-') {
+			j++
+		}
+		k := digits(j)
+		if k == j {
+			return i
+		}
+		j = k
+	}
+
+	return j
+}
@@ -0,0 +1,136 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestDecodePoint(t *testing.T) {
+	for coords, expect := range map[string]geojson.Coord{
+		`[100.0, 0.5]`:               {100.0, 0.5},
+		`[ -100.25 ,0.5e1 , 12E-1 ]`: {-100.25, 5.0, 1.2},
+		`[0,-0]`:                     {0, 0},
+		`[1, 2, 3, 4]`:               {1, 2, 3, 4},
+		`[]`:                         {},
+	} {
+		geo, err := geojson.UnmarshalGeometry([]byte(`{"type":"Point","coordinates":` + coords + `}`))
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equiv(geo.(*geojson.Point).Coords, expect),
+		)
+	}
+}
+
+func TestDecodeIntegerCoordinates(t *testing.T) {
+	for ints, floats := range map[string]string{
+		`{"type":"Point","coordinates":[102,0]}`:                                      `{"type":"Point","coordinates":[102.0,0.0]}`,
+		`{"type":"Point","coordinates":[-102,-1,10]}`:                                 `{"type":"Point","coordinates":[-102.0,-1.0,10.0]}`,
+		`{"type":"MultiPoint","coordinates":[[100,0],[101,1]]}`:                       `{"type":"MultiPoint","coordinates":[[100.0,0.0],[101.0,1.0]]}`,
+		`{"type":"LineString","coordinates":[[100,0],[101,1]]}`:                       `{"type":"LineString","coordinates":[[100.0,0.0],[101.0,1.0]]}`,
+		`{"type":"MultiLineString","coordinates":[[[100,0],[101,1]]]}`:                `{"type":"MultiLineString","coordinates":[[[100.0,0.0],[101.0,1.0]]]}`,
+		`{"type":"Polygon","coordinates":[[[100,0],[101,0],[101,1],[100,0]]]}`:        `{"type":"Polygon","coordinates":[[[100.0,0.0],[101.0,0.0],[101.0,1.0],[100.0,0.0]]]}`,
+		`{"type":"MultiPolygon","coordinates":[[[[100,0],[101,0],[101,1],[100,0]]]]}`: `{"type":"MultiPolygon","coordinates":[[[[100.0,0.0],[101.0,0.0],[101.0,1.0],[100.0,0.0]]]]}`,
+	} {
+		a, err := geojson.UnmarshalGeometry([]byte(ints))
+		it.Then(t).Should(it.Nil(err))
+
+		b, err := geojson.UnmarshalGeometry([]byte(floats))
+		it.Then(t).Should(it.Nil(err))
+
+		ea, err := json.Marshal(a)
+		it.Then(t).Should(it.Nil(err))
+
+		eb, err := json.Marshal(b)
+		it.Then(t).Should(it.Nil(err))
+
+		it.Then(t).Should(
+			it.Equiv(a, b),
+			it.Equal(string(ea), string(eb)),
+		)
+	}
+}
+
+func TestDecodeFeatureIntegerCoordinates(t *testing.T) {
+	var city GeoJsonCity
+	err := json.Unmarshal([]byte(`{"type":"Feature","geometry":{"type":"Point","coordinates":[102,0]},"properties":{"name":"Helsinki"}}`), &city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(city.Geometry, geojson.Geometry(&geojson.Point{Coords: geojson.Coord{102.0, 0.0}})),
+	)
+
+	b, err := json.Marshal(city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(b)).Contain(`"coordinates":[102,0]`),
+	)
+}
+
+func TestDecodePointMalformed(t *testing.T) {
+	for _, coords := range []string{
+		`[01, 2]`,
+		`[1., 2]`,
+		`[1, 2,]`,
+		`[1 2]`,
+		`[1e400, 2]`,
+		`["1", 2]`,
+		`[1, 2`,
+	} {
+		_, err := geojson.UnmarshalGeometry([]byte(`{"type":"Point","coordinates":` + coords + `}`))
+		it.Then(t).ShouldNot(
+			it.Nil(err),
+		)
+	}
+}
+
+func BenchmarkDecodePoint(b *testing.B) {
+	const n = 1 << 20
+
+	seq := make([][]byte, n)
+	for i := range seq {
+		seq[i] = []byte(fmt.Sprintf(`{"type":"Point","coordinates":[%f,%f,%d]}`, float64(i%360)-180.0+0.123456, float64(i%180)-90.0+0.654321, i%100))
+	}
+
+	// reference decoder, reflection over coordinates with encoding/json
+	b.Run("Reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, x := range seq {
+				var gen struct {
+					Type   string          `json:"type"`
+					Coords json.RawMessage `json:"coordinates"`
+				}
+				if err := json.Unmarshal(x, &gen); err != nil {
+					b.Fatal(err)
+				}
+
+				var coords geojson.Coord
+				if err := json.Unmarshal(gen.Coords, &coords); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("FastPath", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, x := range seq {
+				if _, err := geojson.UnmarshalGeometry(x); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
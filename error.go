@@ -13,7 +13,25 @@ type Error string
 
 func (err Error) Error() string { return string(err) }
 
-// Supported GeoJSON codec errors
+// Supported GeoJSON codec errors. Failures wrap these values together with
+// a human-readable context, use errors.Is to match them.
 const (
-	ErrUnsupportedType = Error("GeoJSON type is not supported")
+	ErrUnsupportedType     = Error("GeoJSON type is not supported")
+	ErrUnknownGeometryType = Error("GeoJSON geometry type is unknown")
+	ErrEmptyCoordinates    = Error("GeoJSON coordinates are empty")
+	ErrOutOfRange          = Error("GeoJSON coordinate is out of WGS84 range")
+	ErrInvalidPosition     = Error("GeoJSON position is invalid")
+	ErrNonFiniteCoordinate = Error("GeoJSON coordinate is not finite number")
+	ErrInvalidRing         = Error("GeoJSON linear ring is invalid")
+	ErrInvalidGeometry     = Error("GeoJSON geometry is invalid")
+	ErrInvalidWKT          = Error("invalid WKT")
+	ErrInvalidWKB          = Error("invalid WKB")
+	ErrInvalidGeohash      = Error("invalid geohash")
+	ErrInvalidTopoJSON     = Error("invalid TopoJSON")
+	ErrInvalidProperties   = Error("GeoJSON properties are not object")
 )
+
+// ErrorUnsupportedType is an alias of ErrUnsupportedType.
+//
+// Deprecated: use ErrUnsupportedType.
+const ErrorUnsupportedType = ErrUnsupportedType
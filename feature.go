@@ -9,7 +9,13 @@
 package geojson
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
 
 	"github.com/fogfish/curie/v2"
 )
@@ -29,62 +35,596 @@ const TYPE_FEATURE = "Feature"
 //	  geojson.Feature
 //	  Name      string `json:"name,omitempty"`
 //	}
+//
+// The bounding box decoded from GeoJSON is retained at BBox, it takes
+// precedence over the box computed from the geometry.
+//
+// Members of GeoJSON object, which are not defined by the standard
+// ("foreign members"), are retained at Foreign and emitted back on encode.
+//
+// The identifier is either JSON string or number. The numeric identifier
+// is kept at ID in its textual form and emitted back as number.
+//
+// The coordinate reference system of legacy GeoJSON is retained at CRS.
+//
+// The properties are retained as raw JSON at Properties if the feature is
+// decoded without application type (see DecodeGeoJSON and RawFeature),
+// use Unmarshal to decode them on demand.
+//
+// The bounding box of geometry set by SetGeometry is computed once and
+// cached, see SetGeometry.
 type Feature struct {
-	ID       curie.IRI `json:"-"`
-	Geometry Geometry  `json:"-"`
+	ID         curie.IRI                  `json:"-"`
+	BBox       BoundingBox                `json:"-"`
+	Geometry   Geometry                   `json:"-"`
+	CRS        *CRS                       `json:"-"`
+	Foreign    map[string]json.RawMessage `json:"-"`
+	Properties json.RawMessage            `json:"-"`
+	numericID  bool
+	// presence of empty identifier, e.g. "id": ""
+	emptyID bool
+	// bounding box of geometry set by SetGeometry
+	cache *bboxCache
+}
+
+// bboxCache is shared by copies of the feature, the box is computed once
+type bboxCache struct {
+	once sync.Once
+	geo  Geometry
+	bbox BoundingBox
+}
+
+// BoundingBox of the feature, either the stored one or computed from geometry
+func (fea Feature) BoundingBox() BoundingBox {
+	if len(fea.BBox) != 0 {
+		return fea.BBox
+	}
+
+	if fea.Geometry == nil {
+		return nil
+	}
+
+	// Note: the geometry replaced through the field bypasses the cache
+	if fea.cache != nil && fea.cache.geo == fea.Geometry {
+		fea.cache.once.Do(func() { fea.cache.bbox = fea.Geometry.BoundingBox() })
+		return fea.cache.bbox
+	}
+
+	return fea.Geometry.BoundingBox()
+}
+
+// SetGeometry replaces geometry of the feature, the bounding box of the
+// geometry is computed lazily once and cached for repeated BoundingBox
+// calls, e.g. over the collection. The cache is shared by copies of
+// the feature until the geometry is set again.
+//
+// Assigning Geometry field directly disables the cache, the mutation of
+// positions of cached geometry in-place is not detected, call SetGeometry
+// again after such mutation.
+func (fea *Feature) SetGeometry(geo Geometry) {
+	fea.Geometry = geo
+	fea.cache = nil
+
+	// Note: identity of geometry is compared, the cache requires comparable type
+	if geo != nil && reflect.TypeOf(geo).Comparable() {
+		fea.cache = &bboxCache{geo: geo}
+	}
+}
+
+// feature is promoted to types embedding Feature, it gives access to
+// the embedded feature.
+func (fea *Feature) feature() *Feature { return fea }
+
+// FMap applies function over all positions of the feature's geometry,
+// it does nothing if geometry is not defined.
+func (fea Feature) FMap(f func(Coord)) {
+	if fea.Geometry == nil {
+		return
+	}
+
+	fea.Geometry.Geometry().FMap(f)
+}
+
+// CountVertices returns number of positions of the feature's geometry
+func (fea Feature) CountVertices() int {
+	n := 0
+	fea.FMap(func(Coord) { n++ })
+	return n
+}
+
+// Explode multi-geometry into features, one per part of geometry. Parts of
+// GeometryCollection are its child geometries. Features share identifier,
+// foreign members and CRS of the original feature, the stored bounding box
+// is not retained. Single-part and unlocated features return themselves.
+// Properties are defined by application type, which copies them, e.g.
+//
+//	for _, x := range city.Feature.Explode() {
+//	  seq = append(seq, City{Feature: x, Name: city.Name})
+//	}
+func (fea Feature) Explode() []Feature {
+	var parts []Geometry
+
+	switch geo := fea.Geometry.(type) {
+	case *MultiPoint:
+		for _, c := range geo.Coords {
+			parts = append(parts, &Point{Coords: clone(c)})
+		}
+	case *MultiLineString:
+		for _, line := range geo.Coords {
+			parts = append(parts, &LineString{Coords: transformCurve(line, clone)})
+		}
+	case *MultiPolygon:
+		for _, surface := range geo.Coords {
+			parts = append(parts, &Polygon{Coords: transformSurface(surface, clone)})
+		}
+	case *GeometryCollection:
+		parts = geo.Geometries
+	default:
+		return []Feature{fea}
+	}
+
+	seq := make([]Feature, len(parts))
+	for i, part := range parts {
+		x := fea
+		x.BBox = nil
+		x.Geometry = part
+		seq[i] = x
+	}
+	return seq
+}
+
+// Collect merges features into single multi-geometry feature, it is the
+// counterpart of Explode. Points and multi-points become MultiPoint, line
+// strings become MultiLineString, polygons become MultiPolygon; mixed types
+// become GeometryCollection of the geometries. Unlocated features are
+// skipped, positions are copied. The identifier is retained if all features
+// share it, properties, foreign members and CRS are not retained, they are
+// defined by application type, e.g.
+//
+//	cities := City{Feature: geojson.Collect(seq), Name: "Cities"}
+func Collect(features []Feature) Feature {
+	geos := make([]Geometry, 0, len(features))
+	for _, fea := range features {
+		if fea.Geometry != nil {
+			geos = append(geos, fea.Geometry)
+		}
+	}
+
+	var fea Feature
+	if len(features) > 0 && features[0].HasID() {
+		fea.ID, fea.numericID, fea.emptyID = features[0].ID, features[0].numericID, features[0].emptyID
+		for _, x := range features[1:] {
+			if x.ID != fea.ID || x.numericID != fea.numericID {
+				fea.ClearID()
+				break
+			}
+		}
+	}
+
+	if len(geos) > 0 {
+		fea.Geometry = collectGeometries(geos)
+	}
+	return fea
+}
+
+func collectGeometries(geos []Geometry) Geometry {
+	switch geos[0].(type) {
+	case *Point, *MultiPoint:
+		seq := Curve{}
+		for _, geo := range geos {
+			switch v := geo.(type) {
+			case *Point:
+				seq = append(seq, clone(v.Coords))
+			case *MultiPoint:
+				seq = append(seq, transformCurve(v.Coords, clone)...)
+			default:
+				return collectGeometryCollection(geos)
+			}
+		}
+		return &MultiPoint{Coords: seq}
+	case *LineString, *MultiLineString:
+		seq := Surface{}
+		for _, geo := range geos {
+			switch v := geo.(type) {
+			case *LineString:
+				seq = append(seq, transformCurve(v.Coords, clone))
+			case *MultiLineString:
+				seq = append(seq, transformSurface(v.Coords, clone)...)
+			default:
+				return collectGeometryCollection(geos)
+			}
+		}
+		return &MultiLineString{Coords: seq}
+	case *Polygon, *MultiPolygon:
+		seq := Surfaces{}
+		for _, geo := range geos {
+			switch v := geo.(type) {
+			case *Polygon:
+				seq = append(seq, transformSurface(v.Coords, clone))
+			case *MultiPolygon:
+				for _, surface := range v.Coords {
+					seq = append(seq, transformSurface(surface, clone))
+				}
+			default:
+				return collectGeometryCollection(geos)
+			}
+		}
+		return &MultiPolygon{Coords: seq}
+	default:
+		return collectGeometryCollection(geos)
+	}
+}
+
+func collectGeometryCollection(geos []Geometry) Geometry {
+	seq := make([]Geometry, len(geos))
+	for i, geo := range geos {
+		seq[i] = Clone(geo)
+	}
+	return &GeometryCollection{Geometries: seq}
 }
 
-func (fea Feature) BoundingBox() BoundingBox { return fea.Geometry.BoundingBox() }
+// Clone returns copy of the feature with deep copy of geometry and bounding
+// box. Foreign members, CRS and raw properties are copied shallowly, the
+// application properties of type embedding the feature are the caller's
+// responsibility.
+func (fea Feature) Clone() Feature {
+	fea.cache = nil
+	if fea.Geometry != nil {
+		fea.Geometry = Clone(fea.Geometry)
+	}
+	if fea.BBox != nil {
+		fea.BBox = append(BoundingBox(nil), fea.BBox...)
+	}
+	return fea
+}
+
+// Unmarshal decodes raw properties of the feature into the value,
+// it does nothing if properties are not retained.
+func (fea Feature) Unmarshal(v any) error {
+	if fea.Properties == nil {
+		return nil
+	}
+
+	return json.Unmarshal(fea.Properties, v)
+}
+
+// NumericID returns the identifier if it is decoded from JSON number
+func (fea Feature) NumericID() (float64, bool) {
+	if !fea.numericID || !isNumber(fea.ID) {
+		return 0, false
+	}
+
+	val, err := strconv.ParseFloat(string(fea.ID), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return val, true
+}
+
+// HasID checks if the feature has identifier, either non empty ID or
+// the one explicitly set by SetID, SetNumericID or decoded from GeoJSON.
+func (fea Feature) HasID() bool {
+	return fea.emptyID || len(fea.ID) != 0
+}
+
+// SetID defines string identifier of the feature, the identifier is
+// emitted even if it is empty.
+func (fea *Feature) SetID(id curie.IRI) {
+	fea.ID, fea.numericID, fea.emptyID = id, false, len(id) == 0
+}
+
+// SetNumericID defines numeric identifier of the feature, the identifier
+// is emitted even if it is zero.
+func (fea *Feature) SetNumericID(id float64) {
+	fea.ID = curie.IRI(strconv.FormatFloat(id, 'f', -1, 64))
+	fea.numericID, fea.emptyID = true, false
+}
+
+// ClearID removes identifier of the feature
+func (fea *Feature) ClearID() {
+	fea.ID, fea.numericID, fea.emptyID = "", false, false
+}
+
+// encodeID returns JSON representation of the identifier
+func (fea Feature) encodeID(enc *encoder) (json.RawMessage, error) {
+	switch {
+	case !fea.HasID():
+		return nil, nil
+	case fea.numericID && isNumber(fea.ID):
+		return json.RawMessage(fea.ID), nil
+	case enc.prefixes != nil && !fea.emptyID:
+		return json.Marshal(curie.URI(enc.prefixes, fea.ID))
+	default:
+		return json.Marshal(fea.ID)
+	}
+}
+
+// isNumber checks that identifier is still JSON number, the field ID is
+// assignable after the numeric identifier is decoded.
+func isNumber(id curie.IRI) bool {
+	return len(id) > 0 && (id[0] == '-' || (id[0] >= '0' && id[0] <= '9')) && json.Valid([]byte(id))
+}
+
+// decodeID decodes identifier from either JSON string or number
+func (fea *Feature) decodeID(raw json.RawMessage) error {
+	fea.ClearID()
+
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+
+	if raw[0] == '"' {
+		if err := json.Unmarshal(raw, &fea.ID); err != nil {
+			return err
+		}
+		fea.emptyID = len(fea.ID) == 0
+		return nil
+	}
+
+	var num json.Number
+	if err := json.Unmarshal(raw, &num); err != nil {
+		return err
+	}
+
+	fea.ID, fea.numericID = curie.IRI(num), true
+	return nil
+}
+
+// decodeIRI decodes identifier from JSON string of full IRI, it is
+// compacted using prefixes. The compact form [prefix:suffix] is decoded
+// as-is.
+func (fea *Feature) decodeIRI(raw json.RawMessage, prefixes curie.Prefixes) error {
+	var iri string
+	if err := json.Unmarshal(raw, &iri); err != nil {
+		return err
+	}
+
+	if len(iri) > 1 && iri[0] == '[' && iri[len(iri)-1] == ']' {
+		return fea.decodeID(raw)
+	}
+
+	fea.SetID(curie.FromURI(prefixes, iri))
+	return nil
+}
+
+// decodeIDFromProperty sets identifier from the property value, either
+// JSON string or number. Other values are ignored.
+func (fea *Feature) decodeIDFromProperty(properties json.RawMessage, key string) error {
+	if len(properties) == 0 {
+		return nil
+	}
+
+	var bag map[string]json.RawMessage
+	if err := json.Unmarshal(properties, &bag); err != nil {
+		return err
+	}
+
+	raw := bytes.TrimSpace(bag[key])
+	if len(raw) == 0 {
+		return nil
+	}
+
+	switch {
+	case raw[0] == '"':
+		var id string
+		if err := json.Unmarshal(raw, &id); err != nil {
+			return err
+		}
+		fea.SetID(curie.IRI(id))
+		return nil
+	case raw[0] == '-' || (raw[0] >= '0' && raw[0] <= '9'):
+		return fea.decodeID(raw)
+	default:
+		return nil
+	}
+}
 
-// EncodeGeoJSON is a helper function to implement GeoJSON codec
+// EncodeGeoJSON is a helper function to implement GeoJSON codec.
+// Members are emitted in the fixed order: type, id, bbox, geometry,
+// properties, crs, followed by foreign members sorted by name. The raw
+// properties retained at Properties are emitted if props is nil. The
+// properties member is always present, it is null if there are none.
 //
 //	func (x MyType) MarshalJSON() ([]byte, error) {
 //		type tStruct MyType
 //		return x.Feature.EncodeGeoJSON(tStruct(x))
 //	}
 func (fea Feature) EncodeGeoJSON(props any) ([]byte, error) {
-	properties, err := json.Marshal(props)
+	return fea.EncodeGeoJSONWith(props)
+}
+
+// EncodeGeoJSONWith is a helper function to implement GeoJSON codec,
+// it is configurable version of EncodeGeoJSON.
+//
+//	func (x MyType) MarshalJSON() ([]byte, error) {
+//		type tStruct MyType
+//		return x.Feature.EncodeGeoJSONWith(tStruct(x), geojson.WithPrecision(6))
+//	}
+func (fea Feature) EncodeGeoJSONWith(props any, opts ...EncodeOption) ([]byte, error) {
+	enc := newEncoder(opts)
+
+	b, err := fea.encode(enc, props)
 	if err != nil {
 		return nil, err
 	}
 
-	geo := fea.Geometry
-	if geo == nil {
-		geo = &Point{Coords: Coord{}}
+	return enc.output(b, roleFeature), nil
+}
+
+// encode the feature to GeoJSON using encoder configuration
+func (fea Feature) encode(enc *encoder, props any) ([]byte, error) {
+	properties := fea.Properties
+	if props != nil || properties == nil {
+		b, err := json.Marshal(props)
+		if err != nil {
+			return nil, err
+		}
+		properties = b
 	}
 
-	// Note: skip bounding box for the point.
-	var bbox BoundingBox
-	switch geo.(type) {
-	case *Point:
-		bbox = nil
-	default:
-		bbox = geo.BoundingBox()
+	id, err := fea.encodeID(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Note: non-finite coordinates are rejected before json.Marshal, which
+	//       fails without naming the position.
+	if fea.Geometry != nil {
+		if err := validateFinite(fea.Geometry); err != nil {
+			return nil, fmt.Errorf("%s: %w", fea.label(), err)
+		}
+	}
+	if err := validateFiniteBBox(fea.BBox); err != nil {
+		return nil, fmt.Errorf("%s: %w", fea.label(), err)
 	}
 
+	geo := enc.geometry(fea.Geometry)
+	if geo == nil && enc.empty == EmptyGeometryPoint {
+		geo = &Point{Coords: Coord{}}
+	}
+
+	bbox := enc.boundingBox(enc.featureBBox(fea.BBox, geo))
+
 	val := struct {
 		Type       string          `json:"type"`
+		ID         json.RawMessage `json:"id,omitempty"`
 		BBox       BoundingBox     `json:"bbox,omitempty"`
-		ID         curie.IRI       `json:"id,omitempty"`
-		Geometry   Geometry        `json:"geometry,omitempty"`
-		Properties json.RawMessage `json:"properties,omitempty"`
+		Geometry   Geometry        `json:"geometry"`
+		Properties json.RawMessage `json:"properties"`
+		CRS        *CRS            `json:"crs,omitempty"`
 	}{
-		ID:         fea.ID,
+		ID:         id,
 		Type:       TYPE_FEATURE,
 		BBox:       bbox,
 		Geometry:   geo,
 		Properties: properties,
+		CRS:        fea.CRS,
+	}
+
+	b, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeForeignMembers(b, fea.Foreign)
+}
+
+// isReservedMember checks if the member is defined by GeoJSON standard
+func isReservedMember(key string) bool {
+	switch key {
+	case "type", "id", "bbox", "geometry", "properties", "crs":
+		return true
+	default:
+		return false
+	}
+}
+
+// reservedMembers defines the order of reserved members at encoded feature
+var reservedMembers = []string{"type", "id", "bbox", "geometry", "properties", "crs"}
+
+// encodeMembers encodes JSON object, reserved members are emitted
+// in the order of GeoJSON encoder followed by foreign members.
+func encodeMembers(bag map[string]json.RawMessage) ([]byte, error) {
+	buf := bytes.NewBuffer([]byte{'{'})
+	for _, key := range reservedMembers {
+		val, has := bag[key]
+		if !has {
+			continue
+		}
+
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`"` + key + `":`)
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+
+	return encodeForeignMembers(buf.Bytes(), bag)
+}
+
+// encodeForeignMembers appends foreign members to encoded JSON object,
+// members colliding with reserved one are ignored.
+func encodeForeignMembers(b []byte, foreign map[string]json.RawMessage) ([]byte, error) {
+	if len(foreign) == 0 {
+		return b, nil
 	}
 
-	return json.Marshal(val)
+	keys := make([]string, 0, len(foreign))
+	for key := range foreign {
+		if !isReservedMember(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	buf := bytes.NewBuffer(b[:len(b)-1])
+	for _, key := range keys {
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteByte(',')
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(foreign[key])
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
 }
 
 // anyGeoJSON is an internal type used for decode of GeoJSON
 type anyGeoJSON struct {
-	Type       string          `json:"type"`
-	ID         curie.IRI       `json:"id,omitempty"`
-	Geometry   json.RawMessage `json:"geometry,omitempty"`
-	Properties json.RawMessage `json:"properties,omitempty"`
+	Type       string                     `json:"type"`
+	ID         json.RawMessage            `json:"id,omitempty"`
+	BBox       BoundingBox                `json:"bbox,omitempty"`
+	Geometry   json.RawMessage            `json:"geometry,omitempty"`
+	Properties json.RawMessage            `json:"properties,omitempty"`
+	CRS        *CRS                       `json:"crs,omitempty"`
+	Foreign    map[string]json.RawMessage `json:"-"`
+}
+
+// decodeEnvelope decodes members of JSON object in single pass, the
+// sub-trees of geometry and properties are not touched.
+func decodeEnvelope(b []byte) (*anyGeoJSON, error) {
+	var bag map[string]json.RawMessage
+	if err := json.Unmarshal(b, &bag); err != nil {
+		return nil, err
+	}
+
+	any := anyGeoJSON{}
+	for key, val := range bag {
+		switch key {
+		case "type":
+			if err := json.Unmarshal(val, &any.Type); err != nil {
+				return nil, err
+			}
+		case "id":
+			any.ID = val
+		case "bbox":
+			if err := json.Unmarshal(val, &any.BBox); err != nil {
+				return nil, err
+			}
+		case "geometry":
+			any.Geometry = val
+		case "properties":
+			any.Properties = val
+		case "crs":
+			if err := json.Unmarshal(val, &any.CRS); err != nil {
+				return nil, err
+			}
+		default:
+			if any.Foreign == nil {
+				any.Foreign = map[string]json.RawMessage{}
+			}
+			any.Foreign[key] = val
+		}
+	}
+
+	return &any, nil
 }
 
 // DecodeGeoJSON is a helper function to implement GeoJSON codec
@@ -93,10 +633,25 @@ type anyGeoJSON struct {
 //		type tStruct *MyType
 //		return x.Feature.DecodeGeoJSON(b, tStruct(x))
 //	}
+//
+// The properties are retained as raw JSON at Properties if props is nil.
+// The explicit null is retained as JSON null, Properties is nil if
+// the member is absent. The properties member other than JSON object or
+// null fails with ErrInvalidProperties naming the feature.
 func (fea *Feature) DecodeGeoJSON(bytes []byte, props interface{}) error {
-	any := anyGeoJSON{}
+	return fea.DecodeGeoJSONWith(bytes, props)
+}
 
-	if err := json.Unmarshal(bytes, &any); err != nil {
+// DecodeGeoJSONWith is a helper function to implement GeoJSON codec,
+// it is configurable version of DecodeGeoJSON.
+//
+//	func (x *MyType) UnmarshalJSON(b []byte) error {
+//		type tStruct *MyType
+//		return x.Feature.DecodeGeoJSONWith(b, tStruct(x), geojson.WithIDFromProperty("iso_a3"))
+//	}
+func (fea *Feature) DecodeGeoJSONWith(bytes []byte, props interface{}, opts ...DecodeOption) error {
+	any, err := decodeEnvelope(bytes)
+	if err != nil {
 		return err
 	}
 
@@ -104,28 +659,117 @@ func (fea *Feature) DecodeGeoJSON(bytes []byte, props interface{}) error {
 		return ErrUnsupportedType
 	}
 
-	return fea.decodeAnyGeoJSON(&any, props)
+	fea.Foreign = any.Foreign
+
+	return fea.decodeAnyGeoJSON(any, props, newDecoder(opts))
+}
+
+// DecodeGeoJSONLenient is a helper function to implement GeoJSON codec,
+// it is lenient version of DecodeGeoJSON. The bare geometry object is
+// accepted as the feature without identifier and properties. The properties
+// member other than JSON object or null is skipped, props remain zero.
+//
+//	func (x *MyType) UnmarshalJSON(b []byte) error {
+//		type tStruct *MyType
+//		return x.Feature.DecodeGeoJSONLenient(b, tStruct(x))
+//	}
+func (fea *Feature) DecodeGeoJSONLenient(bytes []byte, props interface{}) error {
+	any, err := decodeEnvelope(bytes)
+	if err != nil {
+		return err
+	}
+
+	switch geometryType(any.Type) {
+	case typePoint, typeMultiPoint, typeLineString, typeMultiLineString,
+		typePolygon, typeMultiPolygon, typeGeometryCollection:
+		geo, err := decodeGeometry(bytes)
+		if err != nil {
+			return err
+		}
+
+		*fea = Feature{BBox: any.BBox, Geometry: geo}
+		return nil
+	case TYPE_FEATURE:
+		fea.Foreign = any.Foreign
+		return fea.decodeAnyGeoJSON(any, props, &decoder{lenient: true})
+	default:
+		return ErrUnsupportedType
+	}
 }
 
-func (fea *Feature) decodeAnyGeoJSON(any *anyGeoJSON, props interface{}) error {
+func (fea *Feature) decodeAnyGeoJSON(any *anyGeoJSON, props interface{}, dec *decoder) error {
+	if dec.prefixes != nil && len(any.ID) > 0 && any.ID[0] == '"' {
+		if err := fea.decodeIRI(any.ID, dec.prefixes); err != nil {
+			return err
+		}
+	} else if err := fea.decodeID(any.ID); err != nil {
+		return err
+	}
+
+	if !isPropertiesObject(any.Properties) {
+		if !dec.lenient {
+			return fea.invalidProperties(any.Properties)
+		}
+		any.Properties = nil
+	}
+
+	if dec.idProperty != "" && !fea.HasID() {
+		if err := fea.decodeIDFromProperty(any.Properties, dec.idProperty); err != nil {
+			return err
+		}
+	}
+
 	if any.Geometry != nil {
-		geo, err := decodeGeometry(any.Geometry)
+		geo, err := UnmarshalGeometry(any.Geometry)
 		if err != nil {
 			return err
 		}
+
+		// Note: Point with empty coordinates is an alternative form of
+		//       undefined geometry, see WithEmptyGeometry.
+		if pt, ok := geo.(*Point); ok && len(pt.Coords) == 0 {
+			geo = nil
+		}
 		fea.Geometry = geo
 	}
 
-	if any.Properties != nil {
+	switch {
+	case props == nil:
+		fea.Properties = any.Properties
+	case any.Properties != nil:
 		if err := json.Unmarshal(any.Properties, &props); err != nil {
 			return err
 		}
 	}
 
-	fea.ID = any.ID
+	fea.BBox = any.BBox
+	fea.CRS = any.CRS
 	return nil
 }
 
+// isPropertiesObject checks that properties member is either JSON object
+// or null, the absent member is valid as well.
+func isPropertiesObject(raw json.RawMessage) bool {
+	raw = bytes.TrimLeft(raw, " \t\r\n")
+	return len(raw) == 0 || raw[0] == '{' || bytes.HasPrefix(raw, []byte("null"))
+}
+
+func (fea *Feature) invalidProperties(raw json.RawMessage) error {
+	if len(raw) > 32 {
+		raw = append(raw[:32:32], "..."...)
+	}
+
+	return fmt.Errorf("%w: %s has properties %s", ErrInvalidProperties, fea.label(), raw)
+}
+
+// label of the feature for error messages
+func (fea *Feature) label() string {
+	if !fea.HasID() {
+		return "feature without id"
+	}
+	return "feature " + string(fea.ID)
+}
+
 // New Feature from Geometry
 func New(id curie.IRI, geometry Geometry) Feature {
 	return Feature{ID: id, Geometry: geometry}
@@ -139,6 +783,12 @@ func NewPoint(id curie.IRI, coords Coord) Feature {
 	}
 }
 
+// NewPointLatLng ⟼ Feature[Point], the latitude and longitude are given in
+// natural order, see FromLatLng.
+func NewPointLatLng(id curie.IRI, lat, lng float64) Feature {
+	return NewPoint(id, FromLatLng(lat, lng))
+}
+
 // NewMultiPoint ⟼ Feature[MultiPoint]
 func NewMultiPoint(id curie.IRI, coords Curve) Feature {
 	return Feature{
@@ -172,9 +822,22 @@ func NewPolygon(id curie.IRI, coords Surface) Feature {
 }
 
 // NewMultiPolygon ⟼ Feature[MultiPolygon]
+//
+// Each argument is one polygon, the rings of polygon: exterior ring
+// followed by holes.
+//
+//	geojson.NewMultiPolygon(id, geojson.Surface{exterior1}, geojson.Surface{exterior2, hole2})
 func NewMultiPolygon(id curie.IRI, coords ...Surface) Feature {
 	return Feature{
 		ID:       id,
 		Geometry: &MultiPolygon{Coords: coords},
 	}
 }
+
+// NewGeometryCollection ⟼ Feature[GeometryCollection]
+func NewGeometryCollection(id curie.IRI, geometries ...Geometry) Feature {
+	return Feature{
+		ID:       id,
+		Geometry: &GeometryCollection{Geometries: geometries},
+	}
+}
@@ -10,6 +10,10 @@ package geojson_test
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/fogfish/curie/v2"
@@ -46,6 +50,49 @@ const (
 		}
 	`
 
+	featureWithBBox = `
+		{
+			"type": "Feature",
+			"bbox": [100.0, 0.0, 100.5, 0.5],
+			"geometry": {
+				"type": "LineString",
+				"coordinates": [[100.0, 0.0], [101.0, 1.0]]
+			},
+			"properties": {
+				"name": "Helsinki"
+			}
+		}
+	`
+
+	featureForeign = `
+		{
+			"type": "Feature",
+			"when": {"start": "2024-01-01"},
+			"tippecanoe": {"minzoom": 4},
+			"geometry": {
+				"type": "Point",
+				"coordinates": [102.0, 0.5]
+			},
+			"properties": {
+				"name": "Helsinki"
+			}
+		}
+	`
+
+	featureNumericID = `
+		{
+			"type": "Feature",
+			"id": 12345,
+			"geometry": {
+				"type": "Point",
+				"coordinates": [102.0, 0.5]
+			},
+			"properties": {
+				"name": "Helsinki"
+			}
+		}
+	`
+
 	featurePointEmpty = `
 	{
 		"type": "Feature",
@@ -90,6 +137,112 @@ func TestFeatureDecode(t *testing.T) {
 	)
 }
 
+func TestFeatureDecodeBBox(t *testing.T) {
+	var city GeoJsonCity
+	err := json.Unmarshal([]byte(featureWithBBox), &city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(city.BBox, geojson.BoundingBox{100.0, 0.0, 100.5, 0.5}),
+		it.Equiv(city.BoundingBox(), geojson.BoundingBox{100.0, 0.0, 100.5, 0.5}),
+		it.Equiv(city.Geometry.BoundingBox(), geojson.BoundingBox{100.0, 0.0, 101.0, 1.0}),
+	)
+
+	data, err := json.Marshal(city)
+	it.Then(t).Should(it.Nil(err))
+
+	var c GeoJsonCity
+	err = json.Unmarshal(data, &c)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(c.BBox, city.BBox),
+	)
+}
+
+func TestFeatureForeignMembers(t *testing.T) {
+	var city GeoJsonCity
+	err := json.Unmarshal([]byte(featureForeign), &city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(city.Name, "Helsinki"),
+		it.Equal(len(city.Foreign), 2),
+		it.Equal(string(city.Foreign["tippecanoe"]), `{"minzoom": 4}`),
+	)
+
+	city.Foreign["geometry"] = json.RawMessage(`null`)
+	data, err := json.Marshal(city)
+	it.Then(t).Should(it.Nil(err))
+
+	var c GeoJsonCity
+	err = json.Unmarshal(data, &c)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(len(c.Foreign), 2),
+		it.Equal(string(c.Foreign["when"]), `{"start":"2024-01-01"}`),
+		it.Equal(string(c.Foreign["tippecanoe"]), `{"minzoom":4}`),
+		it.Like(c.Geometry, &geojson.Point{geojson.Coord{102.0, 0.5}}),
+	)
+}
+
+func TestFeatureNumericID(t *testing.T) {
+	var city GeoJsonCity
+	err := json.Unmarshal([]byte(featureNumericID), &city)
+	id, isNumeric := city.NumericID()
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(city.ID, "12345"),
+		it.Equal(isNumeric, true),
+		it.Equal(id, 12345.0),
+	)
+
+	data, err := json.Marshal(city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"id":12345,`),
+	)
+
+	_, isNumeric = geojson.NewPoint(city_helsinki, nil).NumericID()
+	it.Then(t).Should(
+		it.Equal(isNumeric, false),
+	)
+}
+
+func TestFeatureNumericIDReassigned(t *testing.T) {
+	var city GeoJsonCity
+	err := json.Unmarshal([]byte(featureNumericID), &city)
+	it.Then(t).Should(it.Nil(err))
+
+	city.ID = "city:x"
+	_, isNumeric := city.NumericID()
+	data, err := json.Marshal(city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(isNumeric, false),
+		it.String(string(data)).Contain(`"id":"[city:x]",`),
+	)
+
+	city.ID = "42"
+	id, isNumeric := city.NumericID()
+	data, err = json.Marshal(city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(isNumeric, true),
+		it.Equal(id, 42.0),
+		it.String(string(data)).Contain(`"id":42,`),
+	)
+}
+
+func TestFeatureDecodeNullGeometry(t *testing.T) {
+	var city GeoJsonCity
+	err := json.Unmarshal([]byte(`{"type":"Feature","geometry":null,"properties":{"name":"Helsinki"}}`), &city)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(city.Name, "Helsinki"),
+		it.Nil(city.Geometry),
+		it.Equiv(city.BoundingBox(), nil),
+	)
+}
+
 func TestFeatureDecodeEmpty(t *testing.T) {
 	var city GeoJsonCity
 	err := json.Unmarshal([]byte(featurePointEmpty), &city)
@@ -97,7 +250,7 @@ func TestFeatureDecodeEmpty(t *testing.T) {
 	it.Then(t).Should(
 		it.Nil(err),
 		it.Equal(city.Name, "Helsinki"),
-		it.Like(city.Geometry, &geojson.Point{geojson.Coord{}}),
+		it.Nil(city.Geometry),
 	)
 }
 
@@ -137,7 +290,7 @@ func TestFeatureEncodePointEmpty(t *testing.T) {
 		it.Nil(err),
 		it.Equal(c.ID, city_helsinki),
 		it.Equal(c.Name, city.Name),
-		it.Like(c.Geometry, &geojson.Point{geojson.Coord{}}),
+		it.Nil(c.Geometry),
 	)
 }
 
@@ -156,10 +309,36 @@ func TestFeatureEncodeUndefined(t *testing.T) {
 		it.Nil(err),
 		it.Equal(c.ID, ""),
 		it.Equal(c.Name, city.Name),
-		it.Like(c.Geometry, &geojson.Point{geojson.Coord{}}),
+		it.Nil(c.Geometry),
 	)
 }
 
+func TestFeatureEncodeEmptyGeometry(t *testing.T) {
+	fea := geojson.Feature{ID: city_helsinki}
+
+	null, err := fea.EncodeGeoJSON(nil)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(null)).Contain(`"geometry":null`),
+	)
+
+	empty, err := fea.EncodeGeoJSONWith(nil, geojson.WithEmptyGeometry(geojson.EmptyGeometryPoint))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(empty)).Contain(`"geometry":{"type":"Point","coordinates":[]}`),
+	)
+
+	for _, b := range [][]byte{null, empty} {
+		var c GeoJsonCity
+		it.Then(t).Should(
+			it.Nil(json.Unmarshal(b, &c)),
+			it.Equal(c.ID, city_helsinki),
+			it.Nil(c.Geometry),
+			it.Equiv(c.BoundingBox(), nil),
+		)
+	}
+}
+
 func TestFeatureEncodeMultiPoint(t *testing.T) {
 	city := GeoJsonCity{
 		Feature: geojson.NewMultiPoint(city_helsinki,
@@ -302,6 +481,103 @@ func TestFeatureEncodeMultiPolygon(t *testing.T) {
 	)
 }
 
+func TestFeatureNewMultiPolygon(t *testing.T) {
+	poly1 := geojson.Surface{
+		{{102.0, 2.0}, {103.0, 2.0}, {103.0, 3.0}, {102.0, 3.0}, {102.0, 2.0}},
+	}
+	poly2 := geojson.Surface{
+		{{100.0, 0.0}, {101.0, 0.0}, {101.0, 1.0}, {100.0, 1.0}, {100.0, 0.0}},
+		{{100.2, 0.2}, {100.2, 0.8}, {100.8, 0.8}, {100.8, 0.2}, {100.2, 0.2}},
+	}
+
+	city := GeoJsonCity{
+		Feature: geojson.NewMultiPolygon(city_helsinki, poly1, poly2),
+		City:    City{Name: "Helsinki"},
+	}
+
+	data, err := json.Marshal(city)
+	it.Then(t).Should(it.Nil(err))
+
+	var c GeoJsonCity
+	err = json.Unmarshal([]byte(data), &c)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.TypeOf[*geojson.MultiPolygon](c.Geometry),
+	)
+
+	geo := c.Geometry.(*geojson.MultiPolygon)
+	it.Then(t).Should(
+		it.Equal(len(geo.Coords), 2),
+		it.Equal(len(geo.Coords[0]), 1),
+		it.Equal(len(geo.Coords[1]), 2),
+		it.Equiv(geo.Coords, geojson.Surfaces{poly1, poly2}),
+		it.Equiv(c.BoundingBox(), geojson.BoundingBox{100.0, 0.0, 103.0, 3.0}),
+	)
+}
+
+func TestFeatureEncodeGeometryCollection(t *testing.T) {
+	city := GeoJsonCity{
+		Feature: geojson.NewGeometryCollection(city_helsinki,
+			&geojson.Point{Coords: geojson.Coord{100.0, 0.0}},
+			&geojson.LineString{Coords: geojson.Curve{{101.0, 0.0}, {102.0, 1.0}}},
+		),
+		City: City{Name: "Helsinki"},
+	}
+
+	data, err := json.Marshal(city)
+	it.Then(t).Should(it.Nil(err))
+
+	var c GeoJsonCity
+	err = json.Unmarshal([]byte(data), &c)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(c.ID, city_helsinki),
+		it.Equal(c.Name, city.Name),
+		it.TypeOf[*geojson.GeometryCollection](c.Geometry),
+		it.Equiv(c.Geometry, city.Geometry),
+	)
+}
+
+func TestFeatureDecodeGeometryCollection(t *testing.T) {
+	var city GeoJsonCity
+	err := json.Unmarshal([]byte(`{
+		"type": "Feature",
+		"id": "[city:helsinki]",
+		"geometry": {
+			"type": "GeometryCollection",
+			"geometries": [
+				{"type": "Polygon", "coordinates": [[[24.9, 60.1], [25.0, 60.1], [25.0, 60.2], [24.9, 60.1]]]},
+				{"type": "Point", "coordinates": [24.95, 60.15]}
+			]
+		},
+		"properties": {"name": "Helsinki"}
+	}`), &city)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(city.Name, "Helsinki"),
+		it.Equiv(city.Geometry, geojson.Geometry(&geojson.GeometryCollection{
+			Geometries: []geojson.Geometry{
+				&geojson.Polygon{Coords: geojson.Surface{{{24.9, 60.1}, {25.0, 60.1}, {25.0, 60.2}, {24.9, 60.1}}}},
+				&geojson.Point{Coords: geojson.Coord{24.95, 60.15}},
+			},
+		})),
+	)
+
+	for _, geo := range []string{
+		`{"type":"GeometryCollection"}`,
+		`{"type":"GeometryCollection","geometries":null}`,
+		`{"type":"GeometryCollection","geometries":[]}`,
+	} {
+		err := json.Unmarshal([]byte(`{"type":"Feature","geometry":`+geo+`,"properties":{}}`), &city)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equiv(city.Geometry, geojson.Geometry(&geojson.GeometryCollection{Geometries: []geojson.Geometry{}})),
+		)
+	}
+}
+
 func TestFeatureInvalidDecode(t *testing.T) {
 	var city GeoJsonCity
 	err := json.Unmarshal([]byte(featureInvalid), &city)
@@ -313,3 +589,437 @@ func TestFeatureInvalidDecode(t *testing.T) {
 		it.Nil(city.Geometry),
 	)
 }
+
+func TestFeatureDecodeUnsupportedType(t *testing.T) {
+	var city GeoJsonCity
+	err := json.Unmarshal([]byte(`{"type":"FeatureCollection","features":[]}`), &city)
+
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrUnsupportedType)),
+		it.True(errors.Is(err, geojson.ErrorUnsupportedType)),
+	)
+}
+
+func BenchmarkFeatureDecode(b *testing.B) {
+	tags := make([]string, 1000)
+	for i := range tags {
+		tags[i] = fmt.Sprintf(`"tag:%d"`, i)
+	}
+	data := []byte(`{"type":"Feature","id":"[city:helsinki]","geometry":{"type":"Point","coordinates":[24.9384,60.1699]},"properties":{"name":"Helsinki","tags":[` + strings.Join(tags, ",") + `]}}`)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var city GeoJsonCity
+		if err := json.Unmarshal(data, &city); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestFeatureSetGeometry(t *testing.T) {
+	var fea geojson.Feature
+	fea.SetGeometry(&geojson.LineString{Coords: geojson.Curve{{1, 1}, {2, 2}}})
+	cp := fea
+
+	it.Then(t).Should(
+		it.Equiv(fea.BoundingBox(), geojson.BoundingBox{1, 1, 2, 2}),
+		it.Equiv(cp.BoundingBox(), geojson.BoundingBox{1, 1, 2, 2}),
+	)
+
+	fea.SetGeometry(&geojson.Point{Coords: geojson.Coord{3, 3}})
+	it.Then(t).Should(
+		it.Equiv(fea.BoundingBox(), geojson.BoundingBox{3, 3, 3, 3}),
+		it.Equiv(cp.BoundingBox(), geojson.BoundingBox{1, 1, 2, 2}),
+	)
+
+	// the field mutation bypasses the cache
+	fea.Geometry = &geojson.Point{Coords: geojson.Coord{4, 4}}
+	it.Then(t).Should(
+		it.Equiv(fea.BoundingBox(), geojson.BoundingBox{4, 4, 4, 4}),
+	)
+
+	fea.SetGeometry(nil)
+	it.Then(t).Should(
+		it.Equiv(fea.BoundingBox(), nil),
+	)
+}
+
+func BenchmarkFeatureBoundingBox(b *testing.B) {
+	ring := make(geojson.Curve, 0, 1001)
+	for i := 0; i < 1000; i++ {
+		a := 2 * math.Pi * float64(i) / 1000
+		ring = append(ring, geojson.Coord{math.Cos(a), math.Sin(a)})
+	}
+	ring = append(ring, ring[0])
+
+	seq := make([]geojson.Feature, 1000)
+	for i := range seq {
+		seq[i].Geometry = &geojson.Polygon{Coords: geojson.Surface{ring}}
+	}
+	c := geojson.Collection[geojson.Feature]{Features: seq}
+
+	b.Run("Computed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.BoundingBox()
+		}
+	})
+
+	b.Run("Cached", func(b *testing.B) {
+		for i := range c.Features {
+			c.Features[i].SetGeometry(c.Features[i].Geometry)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c.BoundingBox()
+		}
+	})
+}
+
+func TestFeatureEncodeMemberOrder(t *testing.T) {
+	fea := geojson.NewLineString(city_helsinki,
+		geojson.Curve{{24.9384, 60.1699}, {24.9415, 60.1725}},
+	)
+	fea.Foreign = map[string]json.RawMessage{"zoom": []byte(`10`), "layer": []byte(`"city"`)}
+
+	data, err := json.Marshal(GeoJsonCity{Feature: fea, City: City{Name: "Helsinki"}})
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(string(data),
+			`{"type":"Feature",`+
+				`"id":"[city:helsinki]",`+
+				`"bbox":[24.9384,60.1699,24.9415,60.1725],`+
+				`"geometry":{"type":"LineString","coordinates":[[24.9384,60.1699],[24.9415,60.1725]]},`+
+				`"properties":{"name":"Helsinki"},`+
+				`"layer":"city","zoom":10}`,
+		),
+	)
+}
+
+func TestFeatureFMap(t *testing.T) {
+	lng := 0.0
+	fea := geojson.NewLineString(city_helsinki,
+		geojson.Curve{{24.9384, 60.1699}, {24.9415, 60.1725}},
+	)
+	fea.FMap(func(c geojson.Coord) { lng += c.Lng() })
+
+	it.Then(t).Should(
+		it.True(lng > 49.87 && lng < 49.88),
+		it.Equal(fea.CountVertices(), 2),
+		it.Equal(geojson.Feature{}.CountVertices(), 0),
+		it.Equal(geojson.NewPolygon(city_helsinki, coordPolygonWithHole).CountVertices(), 10),
+		it.Equal(
+			geojson.NewGeometryCollection(city_helsinki,
+				&geojson.Point{Coords: coordPoint},
+				&geojson.LineString{Coords: coordLineString},
+			).CountVertices(),
+			1+len(coordLineString),
+		),
+	)
+}
+
+func TestFeatureHasID(t *testing.T) {
+	encode := func(fea geojson.Feature) string {
+		data, err := json.Marshal(GeoJsonCity{Feature: fea})
+		it.Then(t).Should(it.Nil(err))
+		return string(data)
+	}
+
+	fea := geojson.NewPoint("", geojson.Coord{24.9384, 60.1699})
+	it.Then(t).Should(
+		it.Equal(fea.HasID(), false),
+	).ShouldNot(
+		it.String(encode(fea)).Contain(`"id"`),
+	)
+
+	fea.SetNumericID(0)
+	id, isNumeric := fea.NumericID()
+	it.Then(t).Should(
+		it.Equal(fea.HasID(), true),
+		it.Equal(isNumeric, true),
+		it.Equal(id, 0.0),
+		it.String(encode(fea)).Contain(`"id":0,`),
+	)
+
+	fea.SetID("")
+	it.Then(t).Should(
+		it.Equal(fea.HasID(), true),
+		it.String(encode(fea)).Contain(`"id":"",`),
+	)
+
+	fea.ClearID()
+	it.Then(t).Should(
+		it.Equal(fea.HasID(), false),
+	).ShouldNot(
+		it.String(encode(fea)).Contain(`"id"`),
+	)
+
+	var city GeoJsonCity
+	err := json.Unmarshal([]byte(`{"type":"Feature","id":0,"geometry":{"type":"Point","coordinates":[24.9384,60.1699]}}`), &city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(city.HasID(), true),
+		it.String(encode(city.Feature)).Contain(`"id":0,`),
+	)
+
+	err = json.Unmarshal([]byte(`{"type":"Feature","id":"","geometry":{"type":"Point","coordinates":[24.9384,60.1699]}}`), &city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(city.HasID(), true),
+		it.String(encode(city.Feature)).Contain(`"id":"",`),
+	)
+
+	err = json.Unmarshal([]byte(`{"type":"Feature","id":null,"geometry":{"type":"Point","coordinates":[24.9384,60.1699]}}`), &city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(city.HasID(), false),
+	)
+}
+
+func TestFeatureExplode(t *testing.T) {
+	t.Run("MultiPolygon", func(t *testing.T) {
+		fea := geojson.NewMultiPolygon(city_helsinki, coordMultiPolygon...)
+		fea.BBox = geojson.BoundingBox{-180.0, -90.0, 180.0, 90.0}
+		seq := fea.Explode()
+
+		it.Then(t).Should(
+			it.Equal(len(seq), len(coordMultiPolygon)),
+			it.Equal(seq[0].ID, city_helsinki),
+			it.Equiv(seq[0].BBox, nil),
+			it.Equiv(seq[1].Geometry, geojson.Geometry(&geojson.Polygon{Coords: coordMultiPolygon[1]})),
+		)
+	})
+
+	t.Run("MultiPoint", func(t *testing.T) {
+		seq := geojson.NewMultiPoint(city_helsinki, coordMultiPoint).Explode()
+		it.Then(t).Should(
+			it.Equal(len(seq), len(coordMultiPoint)),
+			it.Equiv(seq[0].Geometry, geojson.Geometry(&geojson.Point{Coords: coordMultiPoint[0]})),
+		)
+	})
+
+	t.Run("MultiLineString", func(t *testing.T) {
+		seq := geojson.NewMultiLineString(city_helsinki, coordMultiLineString).Explode()
+		it.Then(t).Should(
+			it.Equal(len(seq), len(coordMultiLineString)),
+			it.Equiv(seq[1].Geometry, geojson.Geometry(&geojson.LineString{Coords: coordMultiLineString[1]})),
+		)
+	})
+
+	t.Run("GeometryCollection", func(t *testing.T) {
+		point := &geojson.Point{Coords: coordPoint}
+		line := &geojson.LineString{Coords: coordLineString}
+		seq := geojson.NewGeometryCollection(city_helsinki, point, line).Explode()
+		it.Then(t).Should(
+			it.Equal(len(seq), 2),
+			it.Equal(seq[0].Geometry, geojson.Geometry(point)),
+			it.Equal(seq[1].Geometry, geojson.Geometry(line)),
+		)
+	})
+
+	t.Run("SinglePart", func(t *testing.T) {
+		fea := geojson.NewPolygon(city_helsinki, coordPolygon)
+		it.Then(t).Should(
+			it.Equiv(fea.Explode(), []geojson.Feature{fea}),
+			it.Equiv(geojson.Feature{ID: city_helsinki}.Explode(), []geojson.Feature{{ID: city_helsinki}}),
+		)
+	})
+}
+
+func TestFeatureCollect(t *testing.T) {
+	t.Run("MultiPoint", func(t *testing.T) {
+		fea := geojson.Collect([]geojson.Feature{
+			geojson.NewPoint(city_helsinki, geojson.Coord{1, 1}),
+			{ID: city_helsinki},
+			geojson.NewMultiPoint(city_helsinki, geojson.Curve{{2, 2}, {3, 3}}),
+		})
+		it.Then(t).Should(
+			it.Equal(fea.ID, city_helsinki),
+			it.Equiv(fea.Geometry, geojson.Geometry(&geojson.MultiPoint{Coords: geojson.Curve{{1, 1}, {2, 2}, {3, 3}}})),
+		)
+	})
+
+	t.Run("MultiLineString", func(t *testing.T) {
+		fea := geojson.Collect([]geojson.Feature{
+			geojson.NewLineString("a:1", coordLineString),
+			geojson.NewLineString("a:2", coordLineString),
+		})
+		it.Then(t).Should(
+			it.True(!fea.HasID()),
+			it.Equiv(fea.Geometry, geojson.Geometry(&geojson.MultiLineString{Coords: geojson.Surface{coordLineString, coordLineString}})),
+		)
+	})
+
+	t.Run("MultiPolygon", func(t *testing.T) {
+		fea := geojson.NewMultiPolygon(city_helsinki, coordPolygon, coordPolygonWithHole)
+		it.Then(t).Should(
+			it.Equiv(geojson.Collect(fea.Explode()), fea),
+		)
+	})
+
+	t.Run("GeometryCollection", func(t *testing.T) {
+		point := &geojson.Point{Coords: coordPoint}
+		line := &geojson.LineString{Coords: coordLineString}
+		fea := geojson.Collect([]geojson.Feature{
+			geojson.New(city_helsinki, point),
+			geojson.New(city_helsinki, line),
+		})
+		it.Then(t).Should(
+			it.Equiv(fea, geojson.NewGeometryCollection(city_helsinki, point, line)),
+		)
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		seq := []geojson.Feature{geojson.NewPoint(city_helsinki, geojson.Coord{1, 1})}
+		fea := geojson.Collect(seq)
+		fea.Geometry.(*geojson.MultiPoint).Coords[0][0] = 10
+		it.Then(t).Should(
+			it.Equiv(seq[0].Geometry, geojson.Geometry(&geojson.Point{Coords: geojson.Coord{1, 1}})),
+		)
+	})
+
+	t.Run("Unlocated", func(t *testing.T) {
+		it.Then(t).Should(
+			it.Equiv(geojson.Collect(nil), geojson.Feature{}),
+			it.Equiv(geojson.Collect([]geojson.Feature{{ID: city_helsinki}}), geojson.Feature{ID: city_helsinki}),
+		)
+	})
+}
+
+type LenientCity struct {
+	geojson.Feature
+	City
+}
+
+func (x *LenientCity) UnmarshalJSON(b []byte) error {
+	type tStruct *LenientCity
+	return x.Feature.DecodeGeoJSONLenient(b, tStruct(x))
+}
+
+func TestFeatureDecodeLenient(t *testing.T) {
+	var city LenientCity
+	err := json.Unmarshal([]byte(`{"type":"Point","coordinates":[24.9384,60.1699]}`), &city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(city.ID, ""),
+		it.Equal(city.Name, ""),
+		it.Equiv(city.Foreign, nil),
+		it.Equiv(city.Geometry, geojson.Geometry(&geojson.Point{Coords: geojson.Coord{24.9384, 60.1699}})),
+	)
+
+	err = json.Unmarshal([]byte(`{"type":"GeometryCollection","bbox":[100.0,0.0,101.0,1.0],"geometries":[{"type":"Point","coordinates":[100.0,0.0]}]}`), &city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(city.BBox, geojson.BoundingBox{100.0, 0.0, 101.0, 1.0}),
+		it.TypeOf[*geojson.GeometryCollection](city.Geometry),
+	)
+
+	err = json.Unmarshal([]byte(featurePoint), &city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(city.Name, "Helsinki"),
+	)
+
+	it.Then(t).Should(
+		it.Equal(json.Unmarshal([]byte(`{"type":"FeatureCollection"}`), &city), error(geojson.ErrUnsupportedType)),
+		it.Equal(json.Unmarshal([]byte(`{"type":"Point"}`), &GeoJsonCity{}), error(geojson.ErrUnsupportedType)),
+	)
+}
+
+func TestFeatureDecodeInvalidProperties(t *testing.T) {
+	for _, props := range []string{`"Helsinki"`, `[1,2]`, `42`, `true`} {
+		b := []byte(`{"type":"Feature","id":"[city:helsinki]","geometry":{"type":"Point","coordinates":[24.9384,60.1699]},"properties":` + props + `}`)
+
+		var strict GeoJsonCity
+		err := json.Unmarshal(b, &strict)
+		it.Then(t).Should(
+			it.True(errors.Is(err, geojson.ErrInvalidProperties)),
+			it.String(err.Error()).Contain(string(city_helsinki)),
+		)
+
+		var lenient LenientCity
+		err = json.Unmarshal(b, &lenient)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(lenient.ID, city_helsinki),
+			it.Equal(lenient.Name, ""),
+			it.Equiv(lenient.Geometry, geojson.Geometry(&geojson.Point{Coords: geojson.Coord{24.9384, 60.1699}})),
+		)
+	}
+
+	var c geojson.Collection[GeoJsonCity]
+	err := json.Unmarshal([]byte(`{"type":"FeatureCollection","features":[{"type":"Feature","geometry":null,"properties":"x"}]}`), &c)
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrInvalidProperties)),
+		it.String(err.Error()).Contain("without id"),
+	)
+}
+
+func TestFeatureEncodeNonFinite(t *testing.T) {
+	fea := geojson.NewPoint(city_helsinki, geojson.Coord{24.9384, math.Inf(1)})
+	_, err := fea.EncodeGeoJSON(nil)
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrNonFiniteCoordinate)),
+		it.String(err.Error()).Contain("feature city:helsinki: "),
+		it.String(err.Error()).Contain("Point position [24.9384 +Inf]"),
+	)
+
+	fea = geojson.Feature{BBox: geojson.BoundingBox{0, 0, math.NaN(), 1}}
+	_, err = fea.EncodeGeoJSON(nil)
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrNonFiniteCoordinate)),
+		it.String(err.Error()).Contain("feature without id: "),
+	)
+
+	c := geojson.Collection[GeoJsonCity]{
+		Features: []GeoJsonCity{{Feature: geojson.NewLineString(city_helsinki, geojson.Curve{{1, 1}, {math.NaN(), 2}})}},
+	}
+	_, err = json.Marshal(&c)
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrNonFiniteCoordinate)),
+	)
+}
+
+func TestFeatureEncodeRawGeometry(t *testing.T) {
+	fea := geojson.New(city_helsinki, geojson.RawGeometry(`{"type": "Point", "coordinates": [24.93841234, 60.16991234]}`))
+
+	b, err := fea.EncodeGeoJSONWith(City{Name: "Helsinki"}, geojson.WithPrecision(2), geojson.WithBBox(geojson.BBoxAlways))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(b)).Contain(`"geometry":{"type":"Point","coordinates":[24.93841234,60.16991234]}`),
+		it.String(string(b)).Contain(`"bbox":[24.94,60.17,24.94,60.17]`),
+	)
+
+	var city GeoJsonCity
+	it.Then(t).Should(
+		it.Nil(json.Unmarshal(b, &city)),
+		it.Equiv(city.Geometry, geojson.Geometry(&geojson.Point{Coords: geojson.Coord{24.93841234, 60.16991234}})),
+	)
+}
+
+func TestFeatureEncodeNullProperties(t *testing.T) {
+	fea := geojson.NewPoint(city_helsinki, geojson.Coord{24.9384, 60.1699})
+
+	b, err := fea.EncodeGeoJSON(nil)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(b)).Contain(`"properties":null`),
+	)
+}
+
+func TestFeatureDecodeNullProperties(t *testing.T) {
+	var null, absent geojson.RawFeature
+	it.Then(t).Should(
+		it.Nil(json.Unmarshal([]byte(`{"type":"Feature","geometry":null,"properties":null}`), &null)),
+		it.Nil(json.Unmarshal([]byte(`{"type":"Feature","geometry":null}`), &absent)),
+		it.Equal(string(null.Properties), "null"),
+		it.True(absent.Properties == nil),
+	)
+
+	for _, fea := range []geojson.RawFeature{null, absent} {
+		b, err := json.Marshal(fea)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.String(string(b)).Contain(`"properties":null`),
+		)
+	}
+}
@@ -0,0 +1,105 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"fmt"
+	"strings"
+)
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Geohash of the point with given precision (length of the hash),
+// precision is bounded to the range of [1, 12].
+func (geo *Point) Geohash(precision int) string {
+	if len(geo.Coords) < 2 {
+		return ""
+	}
+
+	precision = max(1, min(12, precision))
+
+	lat, lng := geo.Coords.LatLng()
+	box := [4]float64{-180.0, -90.0, 180.0, 90.0}
+	hash := make([]byte, precision)
+
+	isLng := true
+	for i := range hash {
+		var ch byte
+		for bit := 0; bit < 5; bit++ {
+			ch <<= 1
+			if isLng {
+				if mid := (box[0] + box[2]) / 2; lng >= mid {
+					ch |= 1
+					box[0] = mid
+				} else {
+					box[2] = mid
+				}
+			} else {
+				if mid := (box[1] + box[3]) / 2; lat >= mid {
+					ch |= 1
+					box[1] = mid
+				} else {
+					box[3] = mid
+				}
+			}
+			isLng = !isLng
+		}
+		hash[i] = geohashAlphabet[ch]
+	}
+
+	return string(hash)
+}
+
+// GeohashBBox returns bounding box of geohash cell
+func GeohashBBox(hash string) (BoundingBox, error) {
+	if len(hash) < 1 || len(hash) > 12 {
+		return nil, fmt.Errorf("%w: %q length is not within [1, 12]", ErrInvalidGeohash, hash)
+	}
+
+	bbox := BoundingBox{-180.0, -90.0, 180.0, 90.0}
+	isLng := true
+	for _, r := range strings.ToLower(hash) {
+		ch := strings.IndexRune(geohashAlphabet, r)
+		if ch < 0 {
+			return nil, fmt.Errorf("%w: %q has invalid character %q", ErrInvalidGeohash, hash, r)
+		}
+
+		for bit := 4; bit >= 0; bit-- {
+			isSet := ch&(1<<bit) != 0
+			if isLng {
+				mid := (bbox[0] + bbox[2]) / 2
+				if isSet {
+					bbox[0] = mid
+				} else {
+					bbox[2] = mid
+				}
+			} else {
+				mid := (bbox[1] + bbox[3]) / 2
+				if isSet {
+					bbox[1] = mid
+				} else {
+					bbox[3] = mid
+				}
+			}
+			isLng = !isLng
+		}
+	}
+
+	return bbox, nil
+}
+
+// PointFromGeohash returns the center of geohash cell
+func PointFromGeohash(hash string) (*Point, error) {
+	bbox, err := GeohashBBox(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Point{Coords: bbox.Center()}, nil
+}
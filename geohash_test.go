@@ -0,0 +1,64 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestGeohash(t *testing.T) {
+	geo := &geojson.Point{Coords: geojson.Coord{-5.60302734375, 42.60498046875}}
+
+	it.Then(t).Should(
+		it.Equal(geo.Geohash(5), "ezs42"),
+		it.Equal(geo.Geohash(0), "e"),
+		it.Equal(len(geo.Geohash(20)), 12),
+		it.Equal((&geojson.Point{}).Geohash(5), ""),
+	)
+}
+
+func TestGeohashBBox(t *testing.T) {
+	bbox, err := geojson.GeohashBBox("ezs42")
+	it.Then(t).Should(
+		it.Nil(err),
+		it.True(near(bbox[0], -5.625, 1e-9)),
+		it.True(near(bbox[1], 42.5830078125, 1e-9)),
+		it.True(near(bbox[2], -5.5810546875, 1e-9)),
+		it.True(near(bbox[3], 42.626953125, 1e-9)),
+	)
+
+	geo, err := geojson.PointFromGeohash("EZS42")
+	it.Then(t).Should(
+		it.Nil(err),
+		it.True(nearCoord(geo.Coords, geojson.Coord{-5.60302734375, 42.60498046875})),
+	)
+}
+
+func TestGeohashCodec(t *testing.T) {
+	hash := (&geojson.Point{Coords: coordHelsinki}).Geohash(9)
+	bbox, err := geojson.GeohashBBox(hash)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.True(bbox.Contains(coordHelsinki)),
+	)
+}
+
+func TestGeohashInvalid(t *testing.T) {
+	for _, hash := range []string{"", "ezs42a", "0123456789bcd"} {
+		_, err := geojson.PointFromGeohash(hash)
+		it.Then(t).Should(
+			it.True(errors.Is(err, geojson.ErrInvalidGeohash)),
+		)
+	}
+}
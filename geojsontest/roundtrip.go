@@ -0,0 +1,137 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+// Package geojsontest implements test helpers for types using GeoJSON codec.
+//
+//	func TestCityCodec(t *testing.T) {
+//	  geojsontest.RoundTrip(t, City{...})
+//	}
+package geojsontest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// RoundTrip checks symmetry of the JSON codec for the value. It encodes
+// the value, decodes it back into the fresh instance of T and encodes it
+// again. Both documents are compared structurally member by member, any
+// member lost or altered by the codec fails the test with its JSON path,
+// e.g. $.features[1].properties.name.
+//
+// Only members of GeoJSON documents are compared, fields not emitted by
+// the codec are not visible to the check.
+func RoundTrip[T any](t testing.TB, v T) {
+	t.Helper()
+
+	a, err := json.Marshal(&v)
+	if err != nil {
+		t.Fatalf("geojsontest: encode %T: %v", v, err)
+		return
+	}
+
+	var w T
+	if err := json.Unmarshal(a, &w); err != nil {
+		t.Fatalf("geojsontest: decode %T: %v\n%s", v, err, a)
+		return
+	}
+
+	b, err := json.Marshal(&w)
+	if err != nil {
+		t.Fatalf("geojsontest: encode decoded %T: %v", v, err)
+		return
+	}
+
+	va, err := decode(a)
+	if err != nil {
+		t.Fatalf("geojsontest: invalid JSON of %T: %v", v, err)
+		return
+	}
+
+	vb, err := decode(b)
+	if err != nil {
+		t.Fatalf("geojsontest: invalid JSON of decoded %T: %v", v, err)
+		return
+	}
+
+	for _, d := range diff("$", va, vb, nil) {
+		t.Errorf("geojsontest: %T is not symmetric: %s", v, d)
+	}
+}
+
+func decode(b []byte) (any, error) {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// diff of JSON values, it reports divergence at member level
+func diff(path string, a, b any, seq []string) []string {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok {
+			return append(seq, fmt.Sprintf("%s is %s, decoded as %s", path, text(a), text(b)))
+		}
+
+		keys := make([]string, 0, len(x)+len(y))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		for k := range y {
+			if _, has := x[k]; !has {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			va, inA := x[k]
+			vb, inB := y[k]
+			switch {
+			case !inB:
+				seq = append(seq, fmt.Sprintf("%s.%s is lost, it was %s", path, k, text(va)))
+			case !inA:
+				seq = append(seq, fmt.Sprintf("%s.%s is added as %s", path, k, text(vb)))
+			default:
+				seq = diff(path+"."+k, va, vb, seq)
+			}
+		}
+		return seq
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return append(seq, fmt.Sprintf("%s is %s, decoded as %s", path, text(a), text(b)))
+		}
+
+		for i := range x {
+			seq = diff(fmt.Sprintf("%s[%d]", path, i), x[i], y[i], seq)
+		}
+		return seq
+	default:
+		if a != b {
+			return append(seq, fmt.Sprintf("%s is %s, decoded as %s", path, text(a), text(b)))
+		}
+		return seq
+	}
+}
+
+func text(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
@@ -0,0 +1,113 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojsontest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/geojson/geojsontest"
+	"github.com/fogfish/it/v2"
+)
+
+type City struct {
+	geojson.Feature
+	Name string `json:"name,omitempty"`
+}
+
+func (x City) MarshalJSON() ([]byte, error) {
+	type tStruct City
+	return x.Feature.EncodeGeoJSON(tStruct(x))
+}
+
+func (x *City) UnmarshalJSON(b []byte) error {
+	type tStruct *City
+	return x.Feature.DecodeGeoJSON(b, tStruct(x))
+}
+
+// the codec drops foreign members and upper cases the name
+type LossyCity struct {
+	geojson.Feature
+	Name string `json:"name,omitempty"`
+}
+
+func (x LossyCity) MarshalJSON() ([]byte, error) {
+	type tStruct LossyCity
+	return x.Feature.EncodeGeoJSON(tStruct(x))
+}
+
+func (x *LossyCity) UnmarshalJSON(b []byte) error {
+	type tStruct *LossyCity
+	if err := x.Feature.DecodeGeoJSON(b, tStruct(x)); err != nil {
+		return err
+	}
+	x.Foreign = nil
+	x.Name = x.Name + "!"
+	return nil
+}
+
+// the codec fails to decode own output
+type BrokenCity struct{ City }
+
+func (x *BrokenCity) UnmarshalJSON(b []byte) error {
+	return geojson.ErrUnsupportedType
+}
+
+// recorder of failures
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func helsinki() geojson.Feature {
+	fea := geojson.NewPoint("city:helsinki", geojson.Coord{24.9384, 60.1699})
+	fea.Foreign = map[string]json.RawMessage{"country": json.RawMessage(`"FI"`)}
+	return fea
+}
+
+func TestRoundTrip(t *testing.T) {
+	geojsontest.RoundTrip(t, City{Feature: helsinki(), Name: "Helsinki"})
+	geojsontest.RoundTrip(t, geojson.Collection[City]{
+		Features: []City{{Feature: helsinki(), Name: "Helsinki"}},
+	})
+	geojsontest.RoundTrip(t, geojson.WithProperties(helsinki(), map[string]any{"name": "Helsinki"}))
+}
+
+func TestRoundTripLossy(t *testing.T) {
+	r := &recorder{TB: t}
+	geojsontest.RoundTrip(r, LossyCity{Feature: helsinki(), Name: "Helsinki"})
+
+	it.Then(t).Should(
+		it.Equal(len(r.errors), 2),
+		it.String(r.errors[0]).Contain(`$.country is lost, it was "FI"`),
+		it.String(r.errors[1]).Contain(`$.properties.name is "Helsinki", decoded as "Helsinki!"`),
+	)
+}
+
+func TestRoundTripDecodeError(t *testing.T) {
+	r := &recorder{TB: t}
+	geojsontest.RoundTrip(r, BrokenCity{City{Feature: helsinki(), Name: "Helsinki"}})
+
+	it.Then(t).Should(
+		it.Equal(len(r.errors), 1),
+		it.String(r.errors[0]).Contain("geojsontest: decode"),
+	)
+}
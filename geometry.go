@@ -9,34 +9,198 @@
 package geojson
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 )
 
 type geometryType string
 
+// GeoJSON geometry types
 const (
-	typePoint           = geometryType("Point")
-	typeMultiPoint      = geometryType("MultiPoint")
-	typeLineString      = geometryType("LineString")
-	typeMultiLineString = geometryType("MultiLineString")
-	typePolygon         = geometryType("Polygon")
-	typeMultiPolygon    = geometryType("MultiPolygon")
+	TYPE_POINT               = string(typePoint)
+	TYPE_MULTI_POINT         = string(typeMultiPoint)
+	TYPE_LINE_STRING         = string(typeLineString)
+	TYPE_MULTI_LINE_STRING   = string(typeMultiLineString)
+	TYPE_POLYGON             = string(typePolygon)
+	TYPE_MULTI_POLYGON       = string(typeMultiPolygon)
+	TYPE_GEOMETRY_COLLECTION = string(typeGeometryCollection)
+)
+
+const (
+	typePoint              = geometryType("Point")
+	typeMultiPoint         = geometryType("MultiPoint")
+	typeLineString         = geometryType("LineString")
+	typeMultiLineString    = geometryType("MultiLineString")
+	typePolygon            = geometryType("Polygon")
+	typeMultiPolygon       = geometryType("MultiPolygon")
+	typeGeometryCollection = geometryType("GeometryCollection")
 )
 
 // Geometry Object represents points, curves, and surfaces in coordinate space.
 // It MUST be one of the seven geometry types.
 type Geometry interface {
+	Type() string
 	Geometry() Shape
 	BoundingBox() BoundingBox
 	unmarshalGeoJSON(b []byte) error
 }
 
-// UnmarshalJSON decodes Geometry from GeoJSON
+// UnmarshalGeometry decodes standalone Geometry object from GeoJSON.
+// It returns nil geometry for JSON null.
+func UnmarshalGeometry(b []byte) (Geometry, error) {
+	if string(bytes.TrimSpace(b)) == "null" {
+		return nil, nil
+	}
+
+	return decodeGeometry(b)
+}
+
+// MarshalGeometry encodes Geometry object to GeoJSON.
+// It returns JSON null for nil geometry.
+func MarshalGeometry(geo Geometry) ([]byte, error) {
+	if geo == nil {
+		return []byte("null"), nil
+	}
+
+	if err := validateFinite(geo); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(geo)
+}
+
+// Geometries is a sequence of heterogeneous geometries, it implements JSON
+// codec dispatching each element by its type. JSON null is decoded as nil
+// geometry and vice versa.
+//
+//	var seq geojson.Geometries
+//	json.Unmarshal([]byte(`[{"type":"Point","coordinates":[1,2]}]`), &seq)
+type Geometries []Geometry
+
+// MarshalJSON encodes geometries as JSON array
+func (seq Geometries) MarshalJSON() ([]byte, error) {
+	if seq == nil {
+		return []byte("null"), nil
+	}
+
+	buf := []byte{'['}
+	for i, geo := range seq {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+
+		b, err := MarshalGeometry(geo)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, b...)
+	}
+	return append(buf, ']'), nil
+}
+
+// UnmarshalJSON decodes geometries from JSON array
+func (seq *Geometries) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if raw == nil {
+		*seq = nil
+		return nil
+	}
+
+	geos := make(Geometries, len(raw))
+	for i, x := range raw {
+		geo, err := UnmarshalGeometry(x)
+		if err != nil {
+			return fmt.Errorf("geometry %d: %w", i, err)
+		}
+		geos[i] = geo
+	}
+
+	*seq = geos
+	return nil
+}
+
+// RawGeometry is pre-serialized GeoJSON geometry object, it is emitted
+// verbatim by the encoder, only insignificant whitespace is removed by
+// json.Marshal. It allows pass-through of geometry without
+// decoding it into concrete type, e.g.
+//
+//	fea := geojson.New(id, geojson.RawGeometry(`{"type":"Point","coordinates":[1,2]}`))
+//
+// The geometry is parsed on demand by Type, Geometry and BoundingBox, each
+// call parses it again. The encoder options (e.g. WithPrecision) are not
+// applied to the raw geometry. Clone, Reproject and other position-wise
+// transforms return it decoded into concrete type, other functions of
+// the package expect concrete types, use UnmarshalGeometry for them.
+// The decoder never produces raw geometry.
+type RawGeometry json.RawMessage
+
+// Type of geometry, it is read from "type" member
+func (geo RawGeometry) Type() string {
+	var bag struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(geo, &bag); err != nil {
+		return ""
+	}
+	return bag.Type
+}
+
+// Geometry decodes the positions, it is empty if geometry is invalid
+func (geo RawGeometry) Geometry() Shape {
+	x, err := geo.decode()
+	if err != nil {
+		return Curve(nil)
+	}
+	return x.Geometry()
+}
+
+// BoundingBox around geometry, it is nil if geometry is invalid
+func (geo RawGeometry) BoundingBox() BoundingBox {
+	x, err := geo.decode()
+	if err != nil {
+		return nil
+	}
+	return x.BoundingBox()
+}
+
+// MarshalJSON emits the geometry as-is
+func (geo RawGeometry) MarshalJSON() ([]byte, error) {
+	if len(geo) == 0 {
+		return []byte("null"), nil
+	}
+	return geo, nil
+}
+
+// Note: the decoder produces concrete types only
+func (geo RawGeometry) unmarshalGeoJSON(b []byte) error { return ErrUnsupportedType }
+
+// decode raw geometry into concrete type
+func (geo RawGeometry) decode() (Geometry, error) {
+	if len(geo) == 0 {
+		return nil, ErrEmptyCoordinates
+	}
+
+	x, err := UnmarshalGeometry(geo)
+	if err != nil {
+		return nil, err
+	}
+	if x == nil {
+		return nil, ErrEmptyCoordinates
+	}
+	return x, nil
+}
+
+// decodeGeometry decodes Geometry from GeoJSON
 func decodeGeometry(b []byte) (Geometry, error) {
 	var gen struct {
-		Type   geometryType    `json:"type"`
-		Coords json.RawMessage `json:"coordinates"`
+		Type       geometryType    `json:"type"`
+		Coords     json.RawMessage `json:"coordinates"`
+		Geometries json.RawMessage `json:"geometries"`
 	}
 	if err := json.Unmarshal(b, &gen); err != nil {
 		return nil, err
@@ -57,19 +221,52 @@ func decodeGeometry(b []byte) (Geometry, error) {
 		geo = &Polygon{}
 	case typeMultiPolygon:
 		geo = &MultiPolygon{}
+	case typeGeometryCollection:
+		geo = &GeometryCollection{}
+		err := geo.unmarshalGeoJSON(gen.Geometries)
+		return geo, err
 	default:
-		return nil, fmt.Errorf("type %s is not supported as GeoJSON %s", gen.Type, "Geometry")
+		return nil, fmt.Errorf("type %s is not supported as GeoJSON %s: %w: %w", gen.Type, "Geometry", ErrUnknownGeometryType, ErrUnsupportedType)
 	}
 
 	err := geo.unmarshalGeoJSON(gen.Coords)
 	return geo, err
 }
 
+// validatePositions checks that positions carry from two to four elements:
+// longitude, latitude, altitude and measure. Shorter positions are rejected
+// at decode, otherwise accessors (e.g. LatLng) fail far from the decoder.
+func validatePositions(t geometryType, shape Shape) error {
+	return shape.FMapErr(func(c Coord) error {
+		switch {
+		case len(c) == 0:
+			return fmt.Errorf("%w: %s has empty position", ErrEmptyCoordinates, t)
+		case len(c) < 2:
+			return fmt.Errorf("%w: %s position %v has %d element, at least 2 required", ErrInvalidPosition, t, c, len(c))
+		case len(c) > 4:
+			return fmt.Errorf("%w: %s position %v has %d elements", ErrInvalidPosition, t, c, len(c))
+		}
+		return nil
+	})
+}
+
+// validatePoint checks position of the point, the empty position is an
+// alternative form of undefined geometry (see WithEmptyGeometry).
+func validatePoint(c Coord) error {
+	if len(c) == 0 {
+		return nil
+	}
+	return validatePositions(typePoint, c)
+}
+
 // Point type, the "coordinates" member is a single position.
 type Point struct {
 	Coords Coord `json:"coordinates"`
 }
 
+// Type of geometry, it is Point
+func (geo *Point) Type() string { return string(typePoint) }
+
 func (geo *Point) Geometry() Shape { return geo.Coords }
 
 // BoundingBox around the point
@@ -106,19 +303,24 @@ func (geo *Point) UnmarshalJSON(b []byte) error {
 	}
 
 	if bag.Type != typePoint {
-		return fmt.Errorf("type %s is not supported as GeoJSON %s", bag.Type, typePoint)
+		return fmt.Errorf("type %s is not supported as GeoJSON %s: %w", bag.Type, typePoint, ErrUnsupportedType)
 	}
 
 	*geo = (Point)(*bag.Struct)
-	return nil
+	return validatePoint(geo.Coords)
 }
 
 // UnmarshalGeoJSON decodes geometry type from GeoJSON
 func (geo *Point) unmarshalGeoJSON(b []byte) error {
+	if coords, ok := decodeCoord(b); ok {
+		geo.Coords = coords
+		return nil
+	}
+
 	if err := json.Unmarshal(b, &geo.Coords); err != nil {
 		return err
 	}
-	return nil
+	return validatePoint(geo.Coords)
 }
 
 // MultiPoint type, the "coordinates" member is an array of positions.
@@ -126,6 +328,9 @@ type MultiPoint struct {
 	Coords Curve `json:"coordinates"`
 }
 
+// Type of geometry, it is MultiPoint
+func (geo *MultiPoint) Type() string { return string(typeMultiPoint) }
+
 func (geo *MultiPoint) Geometry() Shape { return geo.Coords }
 
 // BoundingBox around MultiPoint
@@ -162,11 +367,11 @@ func (geo *MultiPoint) UnmarshalJSON(b []byte) error {
 	}
 
 	if bag.Type != typeMultiPoint {
-		return fmt.Errorf("type %s is not supported as GeoJSON %s", bag.Type, typePoint)
+		return fmt.Errorf("type %s is not supported as GeoJSON %s: %w", bag.Type, typeMultiPoint, ErrUnsupportedType)
 	}
 
 	*geo = (MultiPoint)(*bag.Struct)
-	return nil
+	return validatePositions(typeMultiPoint, geo.Coords)
 }
 
 // UnmarshalGeoJSON decodes geometry type from GeoJSON
@@ -174,7 +379,7 @@ func (geo *MultiPoint) unmarshalGeoJSON(b []byte) error {
 	if err := json.Unmarshal(b, &geo.Coords); err != nil {
 		return err
 	}
-	return nil
+	return validatePositions(typeMultiPoint, geo.Coords)
 }
 
 // LineString type, the "coordinates" member is an array of two or
@@ -183,6 +388,9 @@ type LineString struct {
 	Coords Curve `json:"coordinates"`
 }
 
+// Type of geometry, it is LineString
+func (geo *LineString) Type() string { return string(typeLineString) }
+
 func (geo *LineString) Geometry() Shape { return geo.Coords }
 
 // BoundingBox around LineString
@@ -219,11 +427,11 @@ func (geo *LineString) UnmarshalJSON(b []byte) error {
 	}
 
 	if bag.Type != typeLineString {
-		return fmt.Errorf("type %s is not supported as GeoJSON %s", bag.Type, typePoint)
+		return fmt.Errorf("type %s is not supported as GeoJSON %s: %w", bag.Type, typeLineString, ErrUnsupportedType)
 	}
 
 	*geo = (LineString)(*bag.Struct)
-	return nil
+	return validatePositions(typeLineString, geo.Coords)
 }
 
 // UnmarshalGeoJSON decodes geometry type from GeoJSON
@@ -231,7 +439,7 @@ func (geo *LineString) unmarshalGeoJSON(b []byte) error {
 	if err := json.Unmarshal(b, &geo.Coords); err != nil {
 		return err
 	}
-	return nil
+	return validatePositions(typeLineString, geo.Coords)
 }
 
 // MultiLineString type, the "coordinates" member is an array of
@@ -240,15 +448,23 @@ type MultiLineString struct {
 	Coords Surface `json:"coordinates"`
 }
 
+// Type of geometry, it is MultiLineString
+func (geo *MultiLineString) Type() string { return string(typeMultiLineString) }
+
 func (geo *MultiLineString) Geometry() Shape { return geo.Coords }
 
 // BoundingBox around MultiLineString
 func (geo *MultiLineString) BoundingBox() BoundingBox {
-	if len(geo.Coords) == 0 || len(geo.Coords[0]) == 0 {
-		return nil
+	// Note: the box is seeded from the first non-empty line, the empty
+	//       lines do not contribute to the box.
+	var bbox BoundingBox
+	for _, line := range geo.Coords {
+		if len(line) > 0 {
+			bbox = bbox.Join(boundingBox(line[0], line))
+		}
 	}
 
-	return boundingBox(geo.Coords[0][0], geo.Coords)
+	return bbox
 }
 
 // Encode MultiLineString Geometry to GeoJSON format
@@ -276,11 +492,11 @@ func (geo *MultiLineString) UnmarshalJSON(b []byte) error {
 	}
 
 	if bag.Type != typeMultiLineString {
-		return fmt.Errorf("type %s is not supported as GeoJSON %s", bag.Type, typePoint)
+		return fmt.Errorf("type %s is not supported as GeoJSON %s: %w", bag.Type, typeMultiLineString, ErrUnsupportedType)
 	}
 
 	*geo = (MultiLineString)(*bag.Struct)
-	return nil
+	return validatePositions(typeMultiLineString, geo.Coords)
 }
 
 // UnmarshalGeoJSON decodes geometry type from GeoJSON
@@ -288,7 +504,7 @@ func (geo *MultiLineString) unmarshalGeoJSON(b []byte) error {
 	if err := json.Unmarshal(b, &geo.Coords); err != nil {
 		return err
 	}
-	return nil
+	return validatePositions(typeMultiLineString, geo.Coords)
 }
 
 // Polygon is combinaton of exterior and interior linear rings,
@@ -301,6 +517,9 @@ type Polygon struct {
 	Coords Surface `json:"coordinates"`
 }
 
+// Type of geometry, it is Polygon
+func (geo *Polygon) Type() string { return string(typePolygon) }
+
 func (geo *Polygon) Geometry() Shape { return geo.Coords }
 
 // BoundingBox around Polygon
@@ -312,6 +531,29 @@ func (geo *Polygon) BoundingBox() BoundingBox {
 	return boundingBox(geo.Coords[0][0], geo.Coords)
 }
 
+// Exterior ring of Polygon, it is nil if polygon has no rings
+func (geo *Polygon) Exterior() Curve {
+	if len(geo.Coords) == 0 {
+		return nil
+	}
+	return geo.Coords[0]
+}
+
+// Holes of Polygon, the interior rings following the exterior one
+func (geo *Polygon) Holes() []Curve {
+	if len(geo.Coords) < 2 {
+		return nil
+	}
+	return geo.Coords[1:]
+}
+
+// AddHole appends interior ring to Polygon. The exterior ring shall be
+// defined before, otherwise the ring becomes the exterior one.
+func (geo *Polygon) AddHole(ring Curve) *Polygon {
+	geo.Coords = append(geo.Coords, ring)
+	return geo
+}
+
 // Encode Point Geometry to GeoJSON format
 func (geo *Polygon) MarshalJSON() ([]byte, error) {
 	type Struct Polygon
@@ -337,11 +579,11 @@ func (geo *Polygon) UnmarshalJSON(b []byte) error {
 	}
 
 	if bag.Type != typePolygon {
-		return fmt.Errorf("type %s is not supported as GeoJSON %s", bag.Type, typePoint)
+		return fmt.Errorf("type %s is not supported as GeoJSON %s: %w", bag.Type, typePolygon, ErrUnsupportedType)
 	}
 
 	*geo = (Polygon)(*bag.Struct)
-	return nil
+	return validatePositions(typePolygon, geo.Coords)
 }
 
 // UnmarshalGeoJSON decodes geometry type from GeoJSON
@@ -349,7 +591,7 @@ func (geo *Polygon) unmarshalGeoJSON(b []byte) error {
 	if err := json.Unmarshal(b, &geo.Coords); err != nil {
 		return err
 	}
-	return nil
+	return validatePositions(typePolygon, geo.Coords)
 }
 
 // MultiPolygon type, the "coordinates" member is an array of
@@ -358,19 +600,19 @@ type MultiPolygon struct {
 	Coords Surfaces `json:"coordinates"`
 }
 
+// Type of geometry, it is MultiPolygon
+func (geo *MultiPolygon) Type() string { return string(typeMultiPolygon) }
+
 func (geo *MultiPolygon) Geometry() Shape { return geo.Coords }
 
 // BoundingBox around MultiPolygon
 func (geo *MultiPolygon) BoundingBox() BoundingBox {
-	if len(geo.Coords) == 0 || len(geo.Coords[0]) == 0 {
-		return nil
-	}
-
-	bbox := boundingBox(geo.Coords[0][0][0], geo.Coords[0])
-	for i := 0; i < len(geo.Coords); i++ {
-		surface := geo.Coords[i]
+	// Note: the box is seeded from the first polygon with exterior ring,
+	//       Join might grow the box to 3D, the result is re-assigned.
+	var bbox BoundingBox
+	for _, surface := range geo.Coords {
 		if len(surface) > 0 && len(surface[0]) > 0 {
-			bbox.Join(boundingBox(surface[0][0], surface))
+			bbox = bbox.Join(boundingBox(surface[0][0], surface))
 		}
 	}
 
@@ -402,11 +644,11 @@ func (geo *MultiPolygon) UnmarshalJSON(b []byte) error {
 	}
 
 	if bag.Type != typeMultiPolygon {
-		return fmt.Errorf("type %s is not supported as GeoJSON %s", bag.Type, typePoint)
+		return fmt.Errorf("type %s is not supported as GeoJSON %s: %w", bag.Type, typeMultiPolygon, ErrUnsupportedType)
 	}
 
 	*geo = (MultiPolygon)(*bag.Struct)
-	return nil
+	return validatePositions(typeMultiPolygon, geo.Coords)
 }
 
 // UnmarshalGeoJSON decodes geometry type from GeoJSON
@@ -414,5 +656,88 @@ func (geo *MultiPolygon) unmarshalGeoJSON(b []byte) error {
 	if err := json.Unmarshal(b, &geo.Coords); err != nil {
 		return err
 	}
+	return validatePositions(typeMultiPolygon, geo.Coords)
+}
+
+// GeometryCollection type, the "geometries" member is an array of
+// geometry objects, each of them is one of the geometry types. It is
+// decoded as geometry of Feature as any other type, e.g. polygon bundled
+// with the representative point. The absent or null "geometries" member
+// is decoded as empty collection.
+type GeometryCollection struct {
+	Geometries []Geometry `json:"geometries"`
+}
+
+// Type of geometry, it is GeometryCollection
+func (geo *GeometryCollection) Type() string { return string(typeGeometryCollection) }
+
+func (geo *GeometryCollection) Geometry() Shape {
+	seq := make(Shapes, len(geo.Geometries))
+	for i, x := range geo.Geometries {
+		seq[i] = x.Geometry()
+	}
+	return seq
+}
+
+// BoundingBox around GeometryCollection, union of child bounding boxes
+func (geo *GeometryCollection) BoundingBox() BoundingBox {
+	return UnionBoundingBox(geo.Geometries...)
+}
+
+// Encode GeometryCollection to GeoJSON format
+func (geo *GeometryCollection) MarshalJSON() ([]byte, error) {
+	seq := geo.Geometries
+	if seq == nil {
+		seq = []Geometry{}
+	}
+
+	return json.Marshal(&struct {
+		Type       geometryType `json:"type"`
+		Geometries []Geometry   `json:"geometries"`
+	}{
+		Type:       typeGeometryCollection,
+		Geometries: seq,
+	})
+}
+
+// Decode GeometryCollection from GeoJSON format
+func (geo *GeometryCollection) UnmarshalJSON(b []byte) error {
+	var bag struct {
+		Type       geometryType    `json:"type"`
+		Geometries json.RawMessage `json:"geometries"`
+	}
+
+	if err := json.Unmarshal(b, &bag); err != nil {
+		return err
+	}
+
+	if bag.Type != typeGeometryCollection {
+		return fmt.Errorf("type %s is not supported as GeoJSON %s: %w", bag.Type, typeGeometryCollection, ErrUnsupportedType)
+	}
+
+	return geo.unmarshalGeoJSON(bag.Geometries)
+}
+
+// UnmarshalGeoJSON decodes geometry type from GeoJSON
+func (geo *GeometryCollection) unmarshalGeoJSON(b []byte) error {
+	if len(b) == 0 {
+		geo.Geometries = []Geometry{}
+		return nil
+	}
+
+	var seq []json.RawMessage
+	if err := json.Unmarshal(b, &seq); err != nil {
+		return err
+	}
+
+	geo.Geometries = make([]Geometry, 0, len(seq))
+	for _, raw := range seq {
+		x, err := decodeGeometry(raw)
+		if err != nil {
+			return err
+		}
+		geo.Geometries = append(geo.Geometries, x)
+	}
+
 	return nil
 }
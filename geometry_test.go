@@ -10,6 +10,8 @@ package geojson_test
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"testing"
 
 	"github.com/fogfish/geojson"
@@ -139,6 +141,11 @@ func testGeometry[T geojson.Geometry](
 				},
 			).Contain("type Unknown is not supported"),
 		)
+
+		err := json.Unmarshal(genGeoJSON("Unknown", coord), geo)
+		it.Then(t).Should(
+			it.True(errors.Is(err, geojson.ErrUnsupportedType)),
+		)
 	})
 
 	t.Run("Corrupted", func(t *testing.T) {
@@ -162,6 +169,19 @@ func TestGeometryPointEmpty(t *testing.T) {
 	testGeometry[*geojson.Point](t, "Point", geojson.Coord{}, nil)
 }
 
+func TestGeometryPointAlt(t *testing.T) {
+	testGeometry[*geojson.Point](t, "Point", geojson.Coord{100.0, 0.0, 12.345678},
+		geojson.BoundingBox{100.0, 0, 12.345678, 100.0, 0, 12.345678},
+	)
+}
+
+func TestGeometryLineStringAlt(t *testing.T) {
+	testGeometry[*geojson.LineString](t, "LineString",
+		geojson.Curve{{100.0, 0.0, 10.0}, {101.0, 1.0}, {102.0, 2.0, -5.0}},
+		geojson.BoundingBox{100.0, 0, -5.0, 102.0, 2.0, 10.0},
+	)
+}
+
 func TestGeometryMultiPoint(t *testing.T) {
 	testGeometry[*geojson.MultiPoint](t, "MultiPoint", coordMultiPoint,
 		geojson.BoundingBox{100.0, 0, 101.0, 1.0},
@@ -208,6 +228,28 @@ func TestGeometryPolygonWithHole(t *testing.T) {
 	)
 }
 
+func TestPolygonRings(t *testing.T) {
+	empty := &geojson.Polygon{}
+	it.Then(t).Should(
+		it.Equiv(empty.Exterior(), nil),
+		it.Equal(len(empty.Holes()), 0),
+	)
+
+	solid := &geojson.Polygon{Coords: coordPolygon}
+	it.Then(t).Should(
+		it.Equiv(solid.Exterior(), coordPolygon[0]),
+		it.Equal(len(solid.Holes()), 0),
+	)
+
+	poly := (&geojson.Polygon{Coords: geojson.Surface{coordPolygonWithHole[0]}}).
+		AddHole(coordPolygonWithHole[1])
+	it.Then(t).Should(
+		it.Equiv(poly.Coords, coordPolygonWithHole),
+		it.Equiv(poly.Exterior(), coordPolygonWithHole[0]),
+		it.Equiv(poly.Holes(), []geojson.Curve{coordPolygonWithHole[1]}),
+	)
+}
+
 func TestGeometryMultiPolygon(t *testing.T) {
 	testGeometry[*geojson.MultiPolygon](t, "MultiPolygon", coordMultiPolygon,
 		geojson.BoundingBox{100.0, 0, 103.0, 3.0},
@@ -234,3 +276,265 @@ func TestEmptyGeometry(t *testing.T) {
 		it.Equiv(geojson.NewMultiPolygon("", geojson.Surface{}).BoundingBox(), nil),
 	)
 }
+
+const (
+	geometryCollection = `
+	{
+		"type": "GeometryCollection",
+		"geometries": [
+			{"type": "Point", "coordinates": [100.0, 0.0]},
+			{"type": "LineString", "coordinates": [[101.0, 0.0], [102.0, 1.0]]},
+			{
+				"type": "GeometryCollection",
+				"geometries": [
+					{"type": "Point", "coordinates": [103.0, 3.0]}
+				]
+			}
+		]
+	}`
+
+	geometryCollectionEmpty = `{"type":"GeometryCollection","geometries":[]}`
+)
+
+func TestGeometryCollection(t *testing.T) {
+	var geo geojson.GeometryCollection
+
+	t.Run("Success", func(t *testing.T) {
+		err := json.Unmarshal([]byte(geometryCollection), &geo)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(len(geo.Geometries), 3),
+			it.TypeOf[*geojson.Point](geo.Geometries[0]),
+			it.TypeOf[*geojson.LineString](geo.Geometries[1]),
+			it.TypeOf[*geojson.GeometryCollection](geo.Geometries[2]),
+			it.Equiv(geo.BoundingBox(), geojson.BoundingBox{100.0, 0.0, 103.0, 3.0}),
+		)
+	})
+
+	t.Run("Codec", func(t *testing.T) {
+		b, err := json.Marshal(&geo)
+		it.Then(t).Should(it.Nil(err))
+
+		var c geojson.GeometryCollection
+		err = json.Unmarshal(b, &c)
+
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equiv(c, geo),
+		)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var c geojson.GeometryCollection
+		b, err := json.Marshal(&c)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(string(b), geometryCollectionEmpty),
+		)
+
+		err = json.Unmarshal([]byte(geometryCollectionEmpty), &c)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(len(c.Geometries), 0),
+			it.Equiv(c.BoundingBox(), nil),
+		)
+	})
+
+	t.Run("Not Supported", func(t *testing.T) {
+		it.Then(t).Should(
+			it.Fail(
+				func() error {
+					return json.Unmarshal(genGeoJSON("Unknown", coordPoint), &geo)
+				},
+			).Contain("type Unknown is not supported"),
+		)
+	})
+}
+
+func TestGeometryType(t *testing.T) {
+	it.Then(t).Should(
+		it.Equal((&geojson.Point{}).Type(), geojson.TYPE_POINT),
+		it.Equal((&geojson.MultiPoint{}).Type(), geojson.TYPE_MULTI_POINT),
+		it.Equal((&geojson.LineString{}).Type(), geojson.TYPE_LINE_STRING),
+		it.Equal((&geojson.MultiLineString{}).Type(), geojson.TYPE_MULTI_LINE_STRING),
+		it.Equal((&geojson.Polygon{}).Type(), geojson.TYPE_POLYGON),
+		it.Equal((&geojson.MultiPolygon{}).Type(), geojson.TYPE_MULTI_POLYGON),
+		it.Equal((&geojson.GeometryCollection{}).Type(), geojson.TYPE_GEOMETRY_COLLECTION),
+		it.Equal(geojson.TYPE_MULTI_POLYGON, "MultiPolygon"),
+	)
+}
+
+func TestUnmarshalGeometry(t *testing.T) {
+	geo, err := geojson.UnmarshalGeometry(genGeoJSON("Point", coordPoint))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(geo, geojson.Geometry(&geojson.Point{Coords: coordPoint})),
+	)
+
+	geo, err = geojson.UnmarshalGeometry([]byte(geometryCollection))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.TypeOf[*geojson.GeometryCollection](geo),
+	)
+
+	geo, err = geojson.UnmarshalGeometry([]byte(" null "))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.True(geo == nil),
+	)
+
+	_, err = geojson.UnmarshalGeometry(genGeoJSON("Unknown", coordPoint))
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrUnsupportedType)),
+		it.True(errors.Is(err, geojson.ErrUnknownGeometryType)),
+	)
+}
+
+func TestMarshalGeometry(t *testing.T) {
+	b, err := geojson.MarshalGeometry(&geojson.Point{Coords: coordPoint})
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(string(b), `{"type":"Point","coordinates":[100,0]}`),
+	)
+
+	b, err = geojson.MarshalGeometry(nil)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(string(b), `null`),
+	)
+}
+
+func TestMarshalGeometryNonFinite(t *testing.T) {
+	_, err := geojson.MarshalGeometry(&geojson.LineString{Coords: geojson.Curve{{1, 1}, {2, math.NaN()}}})
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrNonFiniteCoordinate)),
+		it.String(err.Error()).Contain("LineString position [2 NaN]"),
+	)
+}
+
+func TestGeometryDecodeMeasure(t *testing.T) {
+	geo, err := geojson.UnmarshalGeometry([]byte(`{"type":"LineString","coordinates":[[100.0,0.0,10.0,1.0],[101.0,1.0,20.0,2.0]]}`))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(geo.BoundingBox(), geojson.BoundingBox{100.0, 0.0, 10.0, 101.0, 1.0, 20.0}),
+	)
+
+	for _, b := range []string{
+		`{"type":"Point","coordinates":[100.0,0.0,10.0,1.0,5.0]}`,
+		`{"type":"Polygon","coordinates":[[[100.0,0.0],[101.0,0.0],[101.0,1.0,1.0,1.0,1.0],[100.0,0.0]]]}`,
+	} {
+		_, err := geojson.UnmarshalGeometry([]byte(b))
+		it.Then(t).Should(
+			it.True(errors.Is(err, geojson.ErrInvalidPosition)),
+		)
+	}
+
+	var line geojson.LineString
+	err = json.Unmarshal([]byte(`{"type":"LineString","coordinates":[[100.0,0.0,10.0,1.0,5.0],[101.0,1.0]]}`), &line)
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrInvalidPosition)),
+	)
+}
+
+func TestGeometryDecodeShortPosition(t *testing.T) {
+	for b, expect := range map[string]error{
+		`{"type":"Point","coordinates":[102.0]}`:                                             geojson.ErrInvalidPosition,
+		`{"type":"MultiPoint","coordinates":[[102.0,0.5],[]]}`:                               geojson.ErrEmptyCoordinates,
+		`{"type":"LineString","coordinates":[[102.0,0.0],[103.0]]}`:                          geojson.ErrInvalidPosition,
+		`{"type":"MultiLineString","coordinates":[[[102.0,0.0],[103.0,1.0]],[[104.0]]]}`:     geojson.ErrInvalidPosition,
+		`{"type":"Polygon","coordinates":[[[100.0,0.0],[101.0,0.0],[101.0],[100.0,0.0]]]}`:   geojson.ErrInvalidPosition,
+		`{"type":"MultiPolygon","coordinates":[[[[100.0,0.0],[],[101.0,1.0],[100.0,0.0]]]]}`: geojson.ErrEmptyCoordinates,
+	} {
+		_, err := geojson.UnmarshalGeometry([]byte(b))
+		it.Then(t).Should(
+			it.True(errors.Is(err, expect)),
+		)
+	}
+
+	var pt geojson.Point
+	err := json.Unmarshal([]byte(`{"type":"Point","coordinates":[102.0]}`), &pt)
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrInvalidPosition)),
+		it.String(err.Error()).Contain("Point position [102] has 1 element"),
+	)
+
+	var fea GeoJsonCity
+	err = json.Unmarshal([]byte(`{"type":"Feature","geometry":{"type":"LineString","coordinates":[[102.0,0.0],[103.0]]}}`), &fea)
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrInvalidPosition)),
+		it.String(err.Error()).Contain("LineString"),
+	)
+
+	// Note: empty point is undefined geometry
+	err = json.Unmarshal([]byte(`{"type":"Feature","geometry":{"type":"Point","coordinates":[]}}`), &fea)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(fea.Geometry, nil),
+	)
+}
+
+func TestGeometryTypeMismatch(t *testing.T) {
+	var geo geojson.Polygon
+	err := json.Unmarshal(genGeoJSON("LineString", coordPolygon), &geo)
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrUnsupportedType)),
+		it.String(err.Error()).Contain("type LineString is not supported as GeoJSON Polygon"),
+	)
+}
+
+func TestGeometries(t *testing.T) {
+	seq := geojson.Geometries{
+		&geojson.Point{Coords: coordPoint},
+		nil,
+		&geojson.LineString{Coords: coordLineString},
+	}
+
+	b, err := json.Marshal(seq)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(string(b), `[{"type":"Point","coordinates":[100,0]},null,{"type":"LineString","coordinates":[[100,0],[101,1]]}]`),
+	)
+
+	var val geojson.Geometries
+	it.Then(t).Should(
+		it.Nil(json.Unmarshal(b, &val)),
+		it.Equiv(val, seq),
+	)
+
+	var null geojson.Geometries
+	err = json.Unmarshal([]byte(`[{"type":"Point","coordinates":[1,2]},{"type":"Circle"}]`), &val)
+	it.Then(t).Should(
+		it.Nil(json.Unmarshal([]byte(`null`), &null)),
+		it.True(null == nil),
+		it.True(errors.Is(err, geojson.ErrUnknownGeometryType)),
+		it.String(err.Error()).Contain("geometry 1: "),
+	)
+}
+
+func TestRawGeometry(t *testing.T) {
+	raw := geojson.RawGeometry(`{"type":"LineString","coordinates":[[100,0],[101,1]]}`)
+
+	seq := []geojson.Coord{}
+	raw.Geometry().FMap(func(c geojson.Coord) { seq = append(seq, c) })
+
+	it.Then(t).Should(
+		it.Equal(raw.Type(), "LineString"),
+		it.Equiv(raw.BoundingBox(), geojson.BoundingBox{100, 0, 101, 1}),
+		it.Equiv(seq, []geojson.Coord{{100, 0}, {101, 1}}),
+		it.Equiv(geojson.Clone(raw), geojson.Geometry(&geojson.LineString{Coords: coordLineString})),
+	)
+
+	invalid := geojson.RawGeometry(`{"type":"Circle"}`)
+	it.Then(t).Should(
+		it.Equal(invalid.Type(), "Circle"),
+		it.Equiv(invalid.BoundingBox(), nil),
+		it.Equiv(geojson.RawGeometry(nil).BoundingBox(), nil),
+	)
+
+	b, err := geojson.MarshalGeometry(raw)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(string(b), string(raw)),
+	)
+}
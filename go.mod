@@ -1,6 +1,6 @@
 module github.com/fogfish/geojson
 
-go 1.22
+go 1.23
 
 require (
 	github.com/fogfish/curie/v2 v2.0.1
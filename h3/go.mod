@@ -0,0 +1,11 @@
+module github.com/fogfish/geojson/h3
+
+go 1.23
+
+require (
+	github.com/fogfish/geojson v0.0.0-00010101000000-000000000000
+	github.com/fogfish/it/v2 v2.1.0
+	github.com/uber/h3-go/v4 v4.3.0
+)
+
+require github.com/fogfish/curie/v2 v2.0.1 // indirect
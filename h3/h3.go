@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+// Package h3 implements H3 spatial index of GeoJSON points.
+//
+// The package depends on Uber H3 library (cgo), it is the standalone module
+// so that the core of GeoJSON library remains free of cgo:
+//
+//	go get github.com/fogfish/geojson/h3
+package h3
+
+import (
+	"fmt"
+
+	"github.com/fogfish/geojson"
+	h3go "github.com/uber/h3-go/v4"
+)
+
+// ErrInvalidIndex is returned for malformed H3 index
+const ErrInvalidIndex = geojson.Error("invalid H3 index")
+
+// ErrInvalidResolution is returned for resolution out of range [0, 15]
+const ErrInvalidResolution = geojson.Error("invalid H3 resolution")
+
+// Index of the point at given resolution, the resolution is within the
+// range of [0, 15]. Empty index is returned for undefined point.
+func Index(geo *geojson.Point, resolution int) (string, error) {
+	if resolution < 0 || resolution > 15 {
+		return "", fmt.Errorf("%w: %d is out of range [0, 15]", ErrInvalidResolution, resolution)
+	}
+
+	if geo == nil || len(geo.Coords) < 2 {
+		return "", nil
+	}
+
+	lat, lng := geo.Coords.LatLng()
+	cell, err := h3go.LatLngToCell(h3go.NewLatLng(lat, lng), resolution)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidIndex, err)
+	}
+
+	return cell.String(), nil
+}
+
+// Point returns center of H3 cell
+func Point(index string) (*geojson.Point, error) {
+	cell := h3go.Cell(h3go.IndexFromString(index))
+	if !cell.IsValid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidIndex, index)
+	}
+
+	c, err := cell.LatLng()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q %w", ErrInvalidIndex, index, err)
+	}
+
+	return &geojson.Point{Coords: geojson.Coord{c.Lng, c.Lat}}, nil
+}
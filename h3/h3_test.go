@@ -0,0 +1,75 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package h3_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/geojson/h3"
+	"github.com/fogfish/it/v2"
+)
+
+func TestIndex(t *testing.T) {
+	geo := &geojson.Point{Coords: geojson.Coord{-122.41795619597878, 37.775938728915946}}
+
+	index, err := h3.Index(geo, 9)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(index, "8928308280fffff"),
+	)
+
+	for _, res := range []int{0, 15} {
+		index, err := h3.Index(geo, res)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(len(index), 15),
+		)
+	}
+
+	for _, res := range []int{-1, 16} {
+		index, err := h3.Index(geo, res)
+		it.Then(t).Should(
+			it.True(errors.Is(err, h3.ErrInvalidResolution)),
+			it.Equal(index, ""),
+		)
+	}
+
+	for _, geo := range []*geojson.Point{nil, {}} {
+		index, err := h3.Index(geo, 9)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(index, ""),
+		)
+	}
+}
+
+func TestPoint(t *testing.T) {
+	geo, err := h3.Point("8928308280fffff")
+	it.Then(t).Should(
+		it.Nil(err),
+		it.True(math.Abs(geo.Coords.Lng()-(-122.41795619597878)) < 1e-3),
+		it.True(math.Abs(geo.Coords.Lat()-37.775938728915946) < 1e-3),
+	)
+
+	index, err := h3.Index(geo, 9)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(index, "8928308280fffff"),
+	)
+
+	for _, index := range []string{"", "zzz", "0", "8928308280fffffff"} {
+		_, err := h3.Point(index)
+		it.Then(t).Should(
+			it.True(errors.Is(err, h3.ErrInvalidIndex)),
+		)
+	}
+}
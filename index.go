@@ -0,0 +1,121 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"math"
+	"sort"
+)
+
+// node capacity of the index
+const indexNodeSize = 16
+
+// Index is an in-memory spatial index over features of the collection,
+// it is a static R-tree packed using Sort-Tile-Recursive algorithm.
+// The index is not updated when collection is changed.
+type Index[T interface{ BoundingBox() BoundingBox }] struct {
+	features []T
+	root     *indexNode
+}
+
+type indexNode struct {
+	bbox     BoundingBox
+	children []*indexNode
+	// index of the feature at leaf nodes
+	feature int
+}
+
+// BuildIndex builds spatial index over features of the collection,
+// it makes repeated Within queries sub-linear.
+func (c Collection[T]) BuildIndex() *Index[T] {
+	nodes := make([]*indexNode, 0, len(c.Features))
+	for i, x := range c.Features {
+		bbox := BoundingBox(nil).Join(x.BoundingBox())
+		if bbox == nil {
+			continue
+		}
+
+		nodes = append(nodes, &indexNode{bbox: bbox, feature: i})
+	}
+
+	idx := &Index[T]{features: c.Features}
+	if len(nodes) == 0 {
+		return idx
+	}
+
+	for len(nodes) > 1 {
+		nodes = packIndexNodes(nodes)
+	}
+	idx.root = nodes[0]
+
+	return idx
+}
+
+// packIndexNodes builds upper level of the tree
+func packIndexNodes(nodes []*indexNode) []*indexNode {
+	center := func(n *indexNode, axis int) float64 {
+		return n.bbox[axis] + n.bbox[len(n.bbox)/2+axis]
+	}
+
+	leafs := int(math.Ceil(float64(len(nodes)) / indexNodeSize))
+	slices := int(math.Ceil(math.Sqrt(float64(leafs))))
+	sliceSize := slices * indexNodeSize
+
+	sort.SliceStable(nodes, func(i, j int) bool { return center(nodes[i], 0) < center(nodes[j], 0) })
+
+	level := make([]*indexNode, 0, leafs)
+	for s := 0; s < len(nodes); s += sliceSize {
+		slice := nodes[s:min(s+sliceSize, len(nodes))]
+		sort.SliceStable(slice, func(i, j int) bool { return center(slice[i], 1) < center(slice[j], 1) })
+
+		for k := 0; k < len(slice); k += indexNodeSize {
+			children := slice[k:min(k+indexNodeSize, len(slice))]
+
+			var bbox BoundingBox
+			for _, x := range children {
+				bbox = bbox.Join(x.bbox)
+			}
+
+			level = append(level, &indexNode{bbox: bbox, children: children})
+		}
+	}
+
+	return level
+}
+
+// Within returns new collection of features whose bounding box intersects
+// the given one. Features are returned in the order of the collection.
+func (idx *Index[T]) Within(bbox BoundingBox) Collection[T] {
+	found := []int{}
+	if idx.root != nil {
+		found = idx.root.within(bbox, found)
+	}
+	sort.Ints(found)
+
+	seq := make([]T, len(found))
+	for i, k := range found {
+		seq[i] = idx.features[k]
+	}
+	return Collection[T]{Features: seq}
+}
+
+func (node *indexNode) within(bbox BoundingBox, found []int) []int {
+	if !bbox.Intersects(node.bbox) {
+		return found
+	}
+
+	if node.children == nil {
+		return append(found, node.feature)
+	}
+
+	for _, x := range node.children {
+		found = x.within(bbox, found)
+	}
+	return found
+}
@@ -0,0 +1,81 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func testGrid(n int) geojson.Collection[geojson.Feature] {
+	seq := geojson.Collection[geojson.Feature]{}
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			seq.Features = append(seq.Features,
+				geojson.NewPoint(curie.IRI(fmt.Sprintf("p:%d.%d", x, y)), geojson.Coord{float64(x), float64(y)}),
+			)
+		}
+	}
+	return seq
+}
+
+func TestCollectionWithin(t *testing.T) {
+	seq := testCities().Within(geojson.BoundingBox{100.5, 0.5, 105.0, 5.0})
+
+	it.Then(t).Should(
+		it.Equal(len(seq.Features), 2),
+		it.Equal(seq.Features[0].Name, "Helsinki"),
+		it.Equal(seq.Features[1].Name, "Stockholm"),
+	)
+}
+
+func TestIndexWithin(t *testing.T) {
+	grid := testGrid(50)
+	idx := grid.BuildIndex()
+
+	for _, bbox := range []geojson.BoundingBox{
+		{10.0, 10.0, 12.0, 15.0},
+		{-5.0, -5.0, 0.0, 0.0},
+		{48.5, 0.0, 100.0, 100.0},
+		{-10.0, -10.0, 100.0, 100.0},
+		{60.0, 60.0, 70.0, 70.0},
+	} {
+		it.Then(t).Should(
+			it.Equiv(idx.Within(bbox), grid.Within(bbox)),
+		)
+	}
+
+	empty := geojson.Collection[geojson.Feature]{}.BuildIndex()
+	it.Then(t).Should(
+		it.Equal(len(empty.Within(geojson.BoundingBox{0, 0, 1, 1}).Features), 0),
+	)
+}
+
+func BenchmarkCollectionWithin(b *testing.B) {
+	grid := testGrid(300)
+	bbox := geojson.BoundingBox{10.0, 10.0, 12.0, 15.0}
+
+	b.Run("Scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			grid.Within(bbox)
+		}
+	})
+
+	b.Run("Index", func(b *testing.B) {
+		idx := grid.BuildIndex()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			idx.Within(bbox)
+		}
+	})
+}
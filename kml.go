@@ -0,0 +1,231 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// MarshalKML encodes feature or collection to Keyhole Markup Language (KML).
+// The feature becomes <Placemark>, the collection becomes <Document> of
+// placemarks:
+//
+//	<Placemark id="wikipedia:Helsinki">
+//	  <name>Helsinki</name>
+//	  <Point><coordinates>24.9384,60.1699</coordinates></Point>
+//	</Placemark>
+//
+// The value is either Feature or type implementing GeoJSON codec, e.g. type
+// embedding Feature or Collection. The "name" and "description" properties
+// are emitted as <name> and <description>, other properties are omitted.
+// Positions are written as lng,lat[,alt] following KML convention.
+func MarshalKML(v any) ([]byte, error) {
+	doc, err := kmlOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), b...), nil
+}
+
+func kmlOf(v any) (*kml, error) {
+	switch x := v.(type) {
+	case Feature:
+		return &kml{NS: kmlNS, Placemark: kmlPlacemarkOf(x)}, nil
+	case *Feature:
+		return &kml{NS: kmlNS, Placemark: kmlPlacemarkOf(*x)}, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var bag struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(b, &bag); err != nil {
+		return nil, err
+	}
+
+	switch bag.Type {
+	case TYPE_FEATURE:
+		var fea RawFeature
+		if err := json.Unmarshal(b, &fea); err != nil {
+			return nil, err
+		}
+		return &kml{NS: kmlNS, Placemark: kmlPlacemarkOf(fea.Feature)}, nil
+	case TYPE_FEATURE_COLLECTION:
+		var c Collection[RawFeature]
+		var props kmlProperties
+		if err := c.DecodeGeoJSON(b, &props); err != nil {
+			return nil, err
+		}
+
+		doc := &kmlDocument{Name: props.text("name"), Description: props.text("description")}
+		for _, fea := range c.Features {
+			doc.Placemarks = append(doc.Placemarks, *kmlPlacemarkOf(fea.Feature))
+		}
+		return &kml{NS: kmlNS, Document: doc}, nil
+	default:
+		return nil, ErrUnsupportedType
+	}
+}
+
+const kmlNS = "http://www.opengis.net/kml/2.2"
+
+type kml struct {
+	XMLName   xml.Name      `xml:"kml"`
+	NS        string        `xml:"xmlns,attr"`
+	Document  *kmlDocument  `xml:"Document,omitempty"`
+	Placemark *kmlPlacemark `xml:"Placemark,omitempty"`
+}
+
+type kmlDocument struct {
+	Name        string         `xml:"name,omitempty"`
+	Description string         `xml:"description,omitempty"`
+	Placemarks  []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	ID          string `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,omitempty"`
+	Description string `xml:"description,omitempty"`
+	Geometry    any
+}
+
+type kmlPoint struct {
+	XMLName     xml.Name `xml:"Point"`
+	Coordinates string   `xml:"coordinates"`
+}
+
+type kmlLineString struct {
+	XMLName     xml.Name `xml:"LineString"`
+	Coordinates string   `xml:"coordinates"`
+}
+
+type kmlPolygon struct {
+	XMLName xml.Name      `xml:"Polygon"`
+	Outer   kmlBoundary   `xml:"outerBoundaryIs"`
+	Inner   []kmlBoundary `xml:"innerBoundaryIs"`
+}
+
+type kmlBoundary struct {
+	Coordinates string `xml:"LinearRing>coordinates"`
+}
+
+type kmlMultiGeometry struct {
+	XMLName    xml.Name `xml:"MultiGeometry"`
+	Geometries []any
+}
+
+// properties of feature used by KML
+type kmlProperties map[string]any
+
+func (props kmlProperties) text(key string) string {
+	s, _ := props[key].(string)
+	return s
+}
+
+func kmlPlacemarkOf(fea Feature) *kmlPlacemark {
+	var props kmlProperties
+	// Note: properties of unknown schema are optional for KML, the malformed
+	//       ones are ignored.
+	_ = fea.Unmarshal(&props)
+
+	return &kmlPlacemark{
+		ID:          string(fea.ID),
+		Name:        props.text("name"),
+		Description: props.text("description"),
+		Geometry:    kmlGeometryOf(fea.Geometry),
+	}
+}
+
+func kmlGeometryOf(geo Geometry) any {
+	switch v := geo.(type) {
+	case *Point:
+		if len(v.Coords) == 0 {
+			return nil
+		}
+		return kmlPoint{Coordinates: kmlCoords(Curve{v.Coords})}
+	case *MultiPoint:
+		seq := make([]any, len(v.Coords))
+		for i, c := range v.Coords {
+			seq[i] = kmlPoint{Coordinates: kmlCoords(Curve{c})}
+		}
+		return kmlMultiGeometry{Geometries: seq}
+	case *LineString:
+		return kmlLineString{Coordinates: kmlCoords(v.Coords)}
+	case *MultiLineString:
+		seq := make([]any, len(v.Coords))
+		for i, c := range v.Coords {
+			seq[i] = kmlLineString{Coordinates: kmlCoords(c)}
+		}
+		return kmlMultiGeometry{Geometries: seq}
+	case *Polygon:
+		return kmlPolygonOf(v.Coords)
+	case *MultiPolygon:
+		seq := make([]any, len(v.Coords))
+		for i, c := range v.Coords {
+			seq[i] = kmlPolygonOf(c)
+		}
+		return kmlMultiGeometry{Geometries: seq}
+	case *GeometryCollection:
+		seq := make([]any, 0, len(v.Geometries))
+		for _, x := range v.Geometries {
+			if g := kmlGeometryOf(x); g != nil {
+				seq = append(seq, g)
+			}
+		}
+		return kmlMultiGeometry{Geometries: seq}
+	default:
+		return nil
+	}
+}
+
+func kmlPolygonOf(seq Surface) any {
+	if len(seq) == 0 {
+		return nil
+	}
+
+	poly := kmlPolygon{Outer: kmlBoundary{Coordinates: kmlCoords(seq[0])}}
+	for _, hole := range seq[1:] {
+		poly.Inner = append(poly.Inner, kmlBoundary{Coordinates: kmlCoords(hole)})
+	}
+	return poly
+}
+
+// positions as lng,lat[,alt] tuples separated by space
+func kmlCoords(seq Curve) string {
+	var sb strings.Builder
+	for i, c := range seq {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		for k, v := range c {
+			// Note: KML tuple has no measure
+			if k > 2 {
+				break
+			}
+			if k > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+		}
+	}
+	return sb.String()
+}
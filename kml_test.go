@@ -0,0 +1,80 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestMarshalKMLFeature(t *testing.T) {
+	city := GeoJsonCity{
+		Feature: geojson.NewPoint(city_helsinki, geojson.Coord{24.9384, 60.1699, 10.0}),
+		City:    City{Name: "Helsinki"},
+	}
+
+	b, err := geojson.MarshalKML(city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(b)).Contain(xml.Header),
+		it.String(string(b)).Contain(`<kml xmlns="http://www.opengis.net/kml/2.2">`),
+		it.String(string(b)).Contain(`<Placemark id="city:helsinki">`),
+		it.String(string(b)).Contain(`<name>Helsinki</name>`),
+		it.String(string(b)).Contain(`<Point>`),
+		it.String(string(b)).Contain(`<coordinates>24.9384,60.1699,10</coordinates>`),
+	)
+}
+
+func TestMarshalKMLPolygon(t *testing.T) {
+	b, err := geojson.MarshalKML(geojson.New(city_helsinki, &geojson.Polygon{Coords: coordPolygonWithHole}))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(b)).Contain(`<Polygon>`),
+		it.String(string(b)).Contain(`<outerBoundaryIs>`),
+		it.String(string(b)).Contain(`<innerBoundaryIs>`),
+		it.String(string(b)).Contain(`<LinearRing>`),
+		it.String(string(b)).Contain(`<coordinates>100,0 101,0 101,1 100,1 100,0</coordinates>`),
+	)
+}
+
+func TestMarshalKMLGeometry(t *testing.T) {
+	for expect, geo := range map[string]geojson.Geometry{
+		"<LineString>":    &geojson.LineString{Coords: coordLineString},
+		"<MultiGeometry>": &geojson.MultiPoint{Coords: coordMultiPoint},
+		"<Polygon>":       &geojson.MultiPolygon{Coords: coordMultiPolygon},
+	} {
+		b, err := geojson.MarshalKML(geojson.New(city_helsinki, geo))
+		it.Then(t).Should(
+			it.Nil(err),
+			it.String(string(b)).Contain(expect),
+		)
+	}
+}
+
+func TestMarshalKMLCollection(t *testing.T) {
+	cities := testCities()
+	b, err := geojson.MarshalKML(&cities)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(b)).Contain(`<Document>`),
+		it.String(string(b)).Contain(`<Placemark id="city:spb">`),
+		it.String(string(b)).Contain(`<name>Stockholm</name>`),
+		it.String(string(b)).Contain(`<coordinates>101,1</coordinates>`),
+	)
+}
+
+func TestMarshalKMLUnsupported(t *testing.T) {
+	_, err := geojson.MarshalKML(&geojson.Point{Coords: coordPoint})
+	it.Then(t).Should(
+		it.Equal(err, error(geojson.ErrUnsupportedType)),
+	)
+}
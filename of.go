@@ -0,0 +1,67 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+// Of is a feature with application specific properties P, kept at
+// Properties. The type implements GeoJSON codec on its own:
+//
+//	type City struct {
+//		Name string `json:"name,omitempty"`
+//	}
+//
+//	var city geojson.Of[City]
+//	json.Unmarshal(data, &city)
+//
+// Embed Feature into the application type instead if the properties
+// need to be promoted to the top level of the type.
+type Of[P any] struct {
+	Feature
+	Properties P
+}
+
+// WithProperties combines feature with application properties
+//
+//	geojson.WithProperties(geojson.NewPoint(id, coord), City{Name: "Helsinki"})
+func WithProperties[P any](fea Feature, props P) Of[P] {
+	return Of[P]{Feature: fea, Properties: props}
+}
+
+// MarshalJSON encodes the feature as GeoJSON
+func (x Of[P]) MarshalJSON() ([]byte, error) {
+	return x.Feature.EncodeGeoJSON(x.Properties)
+}
+
+// UnmarshalJSON decodes the feature from GeoJSON
+func (x *Of[P]) UnmarshalJSON(b []byte) error {
+	return x.Feature.DecodeGeoJSON(b, &x.Properties)
+}
+
+// RawFeature is a feature which properties are retained as raw JSON at
+// Properties and decoded on demand by Unmarshal. It is useful with stream
+// decoders when properties are only needed for some of features:
+//
+//	r := geojson.NewLineReader[geojson.RawFeature](stream)
+//	fea, err := r.Read()
+//	if fea.BoundingBox().Intersects(bbox) {
+//		var city City
+//		fea.Unmarshal(&city)
+//	}
+type RawFeature struct {
+	Feature
+}
+
+// MarshalJSON encodes the feature as GeoJSON
+func (x RawFeature) MarshalJSON() ([]byte, error) {
+	return x.Feature.EncodeGeoJSON(nil)
+}
+
+// UnmarshalJSON decodes the feature from GeoJSON
+func (x *RawFeature) UnmarshalJSON(b []byte) error {
+	return x.Feature.DecodeGeoJSON(b, nil)
+}
@@ -0,0 +1,126 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestOf(t *testing.T) {
+	city := geojson.Of[City]{
+		Feature:    geojson.NewPoint(city_helsinki, geojson.Coord{24.9384, 60.1699}),
+		Properties: City{Name: "Helsinki"},
+	}
+
+	b, err := json.Marshal(city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(b)).Contain(`"properties":{"name":"Helsinki"}`),
+	)
+
+	var c geojson.Of[City]
+	err = json.Unmarshal(b, &c)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(c.ID, city_helsinki),
+		it.Equal(c.Properties.Name, "Helsinki"),
+		it.Equiv(c.Geometry, geojson.Geometry(&geojson.Point{Coords: geojson.Coord{24.9384, 60.1699}})),
+	)
+}
+
+func TestWithProperties(t *testing.T) {
+	city := geojson.WithProperties(
+		geojson.NewPoint(city_helsinki, geojson.Coord{24.9384, 60.1699}),
+		City{Name: "Helsinki"},
+	)
+
+	b, err := json.Marshal(city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(city.ID, city_helsinki),
+		it.Equal(city.Properties.Name, "Helsinki"),
+		it.String(string(b)).Contain(`"properties":{"name":"Helsinki"}`),
+	)
+}
+
+func TestOfCollection(t *testing.T) {
+	var cities geojson.Collection[geojson.Of[City]]
+	err := json.Unmarshal([]byte(`{"type":"FeatureCollection","features":[`+featurePoint+`]}`), &cities)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(len(cities.Features), 1),
+		it.Equal(cities.Features[0].Properties.Name, "Helsinki"),
+	)
+}
+
+func TestRawFeature(t *testing.T) {
+	var fea geojson.RawFeature
+	err := json.Unmarshal([]byte(featurePoint), &fea)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.TypeOf[*geojson.Point](fea.Geometry),
+		it.String(string(fea.Properties)).Contain(`"Helsinki"`),
+	)
+
+	var city City
+	err = fea.Unmarshal(&city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(city.Name, "Helsinki"),
+	)
+
+	b, err := json.Marshal(fea)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(b)).Contain(`"properties":{"name":"Helsinki"}`),
+	)
+}
+
+func TestRawFeatureStream(t *testing.T) {
+	seq := []string{
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[24.9,60.1]},"properties":{"name":"Helsinki"}}`,
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[18.0,59.3]},"properties":{"name":"Stockholm"}}`,
+	}
+	r := geojson.NewLineReader[geojson.RawFeature](strings.NewReader(strings.Join(seq, "\n")))
+
+	names := []string{}
+	for {
+		fea, err := r.Read()
+		if err != nil {
+			break
+		}
+		if fea.BoundingBox().Intersects(geojson.BoundingBox{20.0, 55.0, 30.0, 65.0}) {
+			var city City
+			it.Then(t).Should(it.Nil(fea.Unmarshal(&city)))
+			names = append(names, city.Name)
+		}
+	}
+
+	it.Then(t).Should(
+		it.Seq(names).Equal("Helsinki"),
+	)
+}
+
+func TestFeatureTypedPropertiesNotRetained(t *testing.T) {
+	var city GeoJsonCity
+	err := json.Unmarshal([]byte(featurePoint), &city)
+
+	var other City
+	it.Then(t).Should(
+		it.Nil(err),
+		it.True(city.Feature.Properties == nil),
+		it.Nil(city.Feature.Unmarshal(&other)),
+		it.Equal(other.Name, ""),
+	)
+}
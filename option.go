@@ -0,0 +1,338 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"math"
+
+	"github.com/fogfish/curie/v2"
+)
+
+// EncodeOption configures GeoJSON encoder
+type EncodeOption func(*encoder)
+
+// encoder configuration
+type encoder struct {
+	// number of decimals for coordinates, -1 disables rounding
+	precision int
+	// emission policy of bounding box
+	bbox BBoxMode
+	// representation of undefined geometry
+	empty EmptyGeometryMode
+	// space after commas within coordinates arrays
+	prettyCoords bool
+	// prefixes to expand compact identifier into IRI
+	prefixes curie.Prefixes
+}
+
+func newEncoder(opts []EncodeOption) *encoder {
+	enc := &encoder{precision: -1, bbox: BBoxAuto, empty: EmptyGeometryNull}
+	for _, opt := range opts {
+		opt(enc)
+	}
+	return enc
+}
+
+// WithPrecision rounds coordinates of geometry and bounding box to n decimals.
+func WithPrecision(n int) EncodeOption {
+	return func(enc *encoder) {
+		enc.precision = n
+	}
+}
+
+// BBoxMode defines emission policy of bounding box
+type BBoxMode int
+
+const (
+	// BBoxAuto emits bounding box for all geometries except points
+	BBoxAuto BBoxMode = iota
+	// BBoxNever suppresses bounding box, including the stored one
+	BBoxNever
+	// BBoxAlways emits bounding box for all geometries, including points
+	BBoxAlways
+)
+
+// WithBBox defines emission policy of bounding box for features and collections.
+func WithBBox(mode BBoxMode) EncodeOption {
+	return func(enc *encoder) {
+		enc.bbox = mode
+	}
+}
+
+// EmptyGeometryMode defines representation of undefined geometry of
+// unlocated features
+type EmptyGeometryMode int
+
+const (
+	// EmptyGeometryNull emits "geometry": null as defined by RFC 7946
+	EmptyGeometryNull EmptyGeometryMode = iota
+	// EmptyGeometryPoint emits Point with empty coordinates
+	// "geometry": {"type": "Point", "coordinates": []}
+	EmptyGeometryPoint
+)
+
+// WithEmptyGeometry defines representation of undefined geometry for
+// unlocated features. The decoder accepts both forms as undefined geometry.
+func WithEmptyGeometry(mode EmptyGeometryMode) EncodeOption {
+	return func(enc *encoder) {
+		enc.empty = mode
+	}
+}
+
+// WithPrettyCoordinates inserts space after commas within coordinates
+// arrays, e.g. [102, 0.5] instead of compact [102,0.5]. Other members of
+// GeoJSON remain compact. It makes fixtures produced by other tools easier
+// to compare.
+//
+// Note: json.Marshal compacts output of MarshalJSON, the option is only
+// visible at the output of EncodeGeoJSONWith called by application, e.g.
+// when writing fixtures. Only coordinates of geometries are spaced, the
+// properties and foreign members are emitted as-is.
+func WithPrettyCoordinates() EncodeOption {
+	return func(enc *encoder) {
+		enc.prettyCoords = true
+	}
+}
+
+// WithIDExpansion emits identifier of the feature as full IRI, the compact
+// form is expanded using prefixes, e.g. city:helsinki becomes
+// "https://example.com/city/helsinki". The expanded IRI is a plain JSON
+// string, use WithIDCompaction to decode it back. Identifier with unknown
+// prefix and numeric identifier are emitted as-is.
+func WithIDExpansion(prefixes curie.Prefixes) EncodeOption {
+	return func(enc *encoder) {
+		enc.prefixes = prefixes
+	}
+}
+
+// isDefault checks that encoder is not configured by options
+func (enc *encoder) isDefault() bool {
+	return enc.precision < 0 && enc.bbox == BBoxAuto && enc.empty == EmptyGeometryNull && enc.prefixes == nil
+}
+
+// output applies whitespace policy to encoded GeoJSON object of the type
+func (enc *encoder) output(b []byte, root jsonRole) []byte {
+	if !enc.prettyCoords {
+		return b
+	}
+
+	return prettyCoordinates(b, root)
+}
+
+// jsonRole is the role of JSON value within GeoJSON structure
+type jsonRole int
+
+const (
+	roleOther jsonRole = iota
+	roleCollection
+	roleFeatures
+	roleFeature
+	roleGeometry
+	roleGeometries
+	roleCoordinates
+)
+
+// role of the object member
+func (r jsonRole) member(key string) jsonRole {
+	switch {
+	case r == roleCollection && key == "features":
+		return roleFeatures
+	case r == roleFeature && key == "geometry":
+		return roleGeometry
+	case r == roleGeometry && key == "geometries":
+		return roleGeometries
+	case r == roleGeometry && key == "coordinates":
+		return roleCoordinates
+	default:
+		return roleOther
+	}
+}
+
+// role of the array element
+func (r jsonRole) element() jsonRole {
+	switch r {
+	case roleFeatures:
+		return roleFeature
+	case roleGeometries:
+		return roleGeometry
+	case roleCoordinates:
+		return roleCoordinates
+	default:
+		return roleOther
+	}
+}
+
+// prettyCoordinates inserts space after commas of "coordinates" arrays.
+// The compact JSON is walked from the root object following GeoJSON
+// structure, only coordinates of geometries are altered, properties and
+// foreign members are copied as-is.
+func prettyCoordinates(b []byte, root jsonRole) []byte {
+	type level struct {
+		role   jsonRole
+		object bool
+	}
+
+	buf := make([]byte, 0, len(b)+len(b)/4)
+	stack := make([]level, 0, 8)
+	next, key, isKey := root, "", false
+
+	for i := 0; i < len(b); i++ {
+		switch c := b[i]; c {
+		case '"':
+			end := jsonStringEnd(b, i)
+			if isKey {
+				key, isKey = string(b[i+1:end]), false
+			}
+			buf = append(buf, b[i:end+1]...)
+			i = end
+		case ':':
+			next = stack[len(stack)-1].role.member(key)
+			buf = append(buf, c)
+		case '{', '[':
+			stack = append(stack, level{role: next, object: c == '{'})
+			next, isKey = next.element(), c == '{'
+			buf = append(buf, c)
+		case '}', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			buf = append(buf, c)
+		case ',':
+			top := stack[len(stack)-1]
+			next, isKey = top.role.element(), top.object
+			if top.role == roleCoordinates && !top.object {
+				buf = append(buf, ',', ' ')
+			} else {
+				buf = append(buf, c)
+			}
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return buf
+}
+
+// index of closing quote of JSON string started at i
+func jsonStringEnd(b []byte, i int) int {
+	for k := i + 1; k < len(b); k++ {
+		switch b[k] {
+		case '\\':
+			k++
+		case '"':
+			return k
+		}
+	}
+	return len(b) - 1
+}
+
+// DecodeOption configures GeoJSON decoder
+type DecodeOption func(*decoder)
+
+// decoder configuration
+type decoder struct {
+	// properties member other than object is skipped
+	lenient bool
+	// property used as identifier of feature without "id" member
+	idProperty string
+	// prefixes to compact IRI into identifier
+	prefixes curie.Prefixes
+}
+
+func newDecoder(opts []DecodeOption) *decoder {
+	dec := &decoder{}
+	for _, opt := range opts {
+		opt(dec)
+	}
+	return dec
+}
+
+// WithIDFromProperty uses the property as identifier of the feature if
+// the "id" member is absent, e.g. "iso_a3" of Natural Earth datasets. The
+// property is either JSON string or number, it is decoded into properties
+// of application type as well. The string is used as-is, it is not parsed
+// as compact IRI.
+func WithIDFromProperty(key string) DecodeOption {
+	return func(dec *decoder) {
+		dec.idProperty = key
+	}
+}
+
+// WithIDCompaction accepts identifier of the feature as full IRI, it is
+// compacted using prefixes, e.g. "https://example.com/city/helsinki"
+// becomes city:helsinki. It is the counterpart of WithIDExpansion, the
+// compact form "[city:helsinki]" is accepted as well.
+func WithIDCompaction(prefixes curie.Prefixes) DecodeOption {
+	return func(dec *decoder) {
+		dec.prefixes = prefixes
+	}
+}
+
+// round coordinates of geometry, if precision is defined
+func (enc *encoder) geometry(geo Geometry) Geometry {
+	if enc.precision < 0 || geo == nil {
+		return geo
+	}
+
+	// Note: raw geometry is emitted verbatim
+	if _, ok := geo.(RawGeometry); ok {
+		return geo
+	}
+
+	return transform(geo, func(c Coord) Coord {
+		x := make(Coord, len(c))
+		for i, v := range c {
+			x[i] = enc.round(v)
+		}
+		return x
+	})
+}
+
+// round coordinates of bounding box, if precision is defined
+func (enc *encoder) boundingBox(bbox BoundingBox) BoundingBox {
+	if enc.precision < 0 || bbox == nil {
+		return bbox
+	}
+
+	box := make(BoundingBox, len(bbox))
+	for i, v := range bbox {
+		box[i] = enc.round(v)
+	}
+	return box
+}
+
+func (enc *encoder) round(v float64) float64 {
+	p := math.Pow10(enc.precision)
+	return math.Round(v*p) / p
+}
+
+// bounding box of the feature defined by emission policy
+func (enc *encoder) featureBBox(bbox BoundingBox, geo Geometry) BoundingBox {
+	if geo == nil {
+		return nil
+	}
+
+	switch enc.bbox {
+	case BBoxNever:
+		return nil
+	case BBoxAlways:
+		if len(bbox) == 0 {
+			bbox = geo.BoundingBox()
+		}
+	default:
+		// Note: skip bounding box for the point.
+		if _, ok := geo.(*Point); len(bbox) == 0 && !ok {
+			bbox = geo.BoundingBox()
+		}
+	}
+
+	if len(bbox) < 4 {
+		return nil
+	}
+	return bbox
+}
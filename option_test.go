@@ -0,0 +1,289 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fogfish/curie/v2"
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestEncodeWithPrecision(t *testing.T) {
+	fea := geojson.NewLineString(city_helsinki,
+		geojson.Curve{
+			{24.93841234567, 60.16991234567},
+			{24.94159876543, 60.17259876543, 12.3456789},
+		},
+	)
+
+	data, err := fea.EncodeGeoJSONWith(City{Name: "Helsinki"}, geojson.WithPrecision(3))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"bbox":[24.938,60.17,12.346,24.942,60.173,12.346]`),
+		it.String(string(data)).Contain(`"coordinates":[[24.938,60.17],[24.942,60.173,12.346]]`),
+	)
+
+	data, err = fea.EncodeGeoJSON(City{Name: "Helsinki"})
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`[24.93841234567,60.16991234567]`),
+		it.Equal(fea.Geometry.(*geojson.LineString).Coords[0][0], 24.93841234567),
+	)
+}
+
+func TestEncodeCollectionWith(t *testing.T) {
+	prefixes := curie.Namespaces{"wikipedia": "https://en.wikipedia.org/wiki/"}
+
+	seq := geojson.Collection[GeoJsonCity]{
+		Features: []GeoJsonCity{
+			{
+				Feature: geojson.NewLineString("wikipedia:Helsinki", geojson.Curve{
+					{24.93841234567, 60.16991234567},
+					{24.94159876543, 60.17259876543},
+				}),
+				City: City{Name: "Helsinki"},
+			},
+			{
+				Feature: geojson.Feature{ID: "wikipedia:Espoo"},
+				City:    City{Name: "Espoo"},
+			},
+		},
+	}
+
+	data, err := seq.EncodeGeoJSONWith(nil,
+		geojson.WithPrecision(2),
+		geojson.WithEmptyGeometry(geojson.EmptyGeometryPoint),
+		geojson.WithIDExpansion(prefixes),
+	)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"bbox":[24.94,60.17,24.94,60.17]`),
+		it.String(string(data)).Contain(`"id":"https://en.wikipedia.org/wiki/Helsinki","bbox":[24.94,60.17,24.94,60.17],"geometry":{"type":"LineString","coordinates":[[24.94,60.17],[24.94,60.17]]},"properties":{"name":"Helsinki"}`),
+		it.String(string(data)).Contain(`"id":"https://en.wikipedia.org/wiki/Espoo","geometry":{"type":"Point","coordinates":[]},"properties":{"name":"Espoo"}`),
+	)
+}
+
+func TestEncodeWithBBox(t *testing.T) {
+	point := geojson.NewPoint(city_helsinki, geojson.Coord{24.9384, 60.1699})
+	line := geojson.NewLineString(city_helsinki,
+		geojson.Curve{{24.9384, 60.1699}, {24.9415, 60.1725}},
+	)
+
+	t.Run("Auto", func(t *testing.T) {
+		a, _ := point.EncodeGeoJSONWith(City{Name: "Helsinki"}, geojson.WithBBox(geojson.BBoxAuto))
+		b, _ := line.EncodeGeoJSONWith(City{Name: "Helsinki"}, geojson.WithBBox(geojson.BBoxAuto))
+		it.Then(t).
+			ShouldNot(it.String(string(a)).Contain(`"bbox"`)).
+			Should(it.String(string(b)).Contain(`"bbox":[24.9384,60.1699,24.9415,60.1725]`))
+	})
+
+	t.Run("Always", func(t *testing.T) {
+		a, _ := point.EncodeGeoJSONWith(City{Name: "Helsinki"}, geojson.WithBBox(geojson.BBoxAlways))
+		it.Then(t).Should(
+			it.String(string(a)).Contain(`"bbox":[24.9384,60.1699,24.9384,60.1699]`),
+		)
+	})
+
+	t.Run("Never", func(t *testing.T) {
+		fea := line
+		fea.BBox = geojson.BoundingBox{-180.0, -90.0, 180.0, 90.0}
+		b, _ := fea.EncodeGeoJSONWith(City{Name: "Helsinki"}, geojson.WithBBox(geojson.BBoxNever))
+		it.Then(t).ShouldNot(
+			it.String(string(b)).Contain(`"bbox"`),
+		)
+	})
+
+	t.Run("Collection", func(t *testing.T) {
+		seq := testCities()
+
+		a, err := seq.EncodeGeoJSONWith(nil, geojson.WithBBox(geojson.BBoxAlways))
+		it.Then(t).Should(
+			it.Nil(err),
+			it.String(string(a)).Contain(`"bbox":[-180,-90,180,90]`),
+			it.String(string(a)).Contain(`{"type":"Feature","id":"[city:hel]","bbox":[101,1,101,1]`),
+		)
+
+		b, err := seq.EncodeGeoJSONWith(nil, geojson.WithBBox(geojson.BBoxNever))
+		it.Then(t).Should(
+			it.Nil(err),
+		).ShouldNot(
+			it.String(string(b)).Contain(`"bbox"`),
+		)
+
+		var c geojson.Collection[GeoJsonCity]
+		it.Then(t).Should(
+			it.Nil(json.Unmarshal(a, &c)),
+			it.Equal(c.Features[1].Name, "Helsinki"),
+			it.Equiv(c.Features[1].BBox, geojson.BoundingBox{101, 1, 101, 1}),
+		)
+	})
+}
+
+func TestEncodeWithPrettyCoordinates(t *testing.T) {
+	fea := geojson.NewLineString(city_helsinki,
+		geojson.Curve{{24.9384, 60.1699}, {24.9415, 60.1725, 12.3}},
+	)
+
+	data, err := fea.EncodeGeoJSONWith(City{Name: "Helsinki, Finland"}, geojson.WithPrettyCoordinates())
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"coordinates":[[24.9384, 60.1699], [24.9415, 60.1725, 12.3]]`),
+		it.String(string(data)).Contain(`"bbox":[24.9384,60.1699,12.3,24.9415,60.1725,12.3]`),
+		it.String(string(data)).Contain(`"name":"Helsinki, Finland"`),
+	)
+
+	c := geojson.Collection[GeoJsonCity]{
+		Features: []GeoJsonCity{{Feature: geojson.NewPoint(city_helsinki, geojson.Coord{102, 0.5})}},
+	}
+	data, err = c.EncodeGeoJSONWith(nil, geojson.WithPrettyCoordinates())
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"coordinates":[102, 0.5]`),
+	)
+
+	data, err = fea.EncodeGeoJSON(nil)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"coordinates":[[24.9384,60.1699],[24.9415,60.1725,12.3]]`),
+	)
+
+	// members of properties are not geometry
+	data, err = fea.EncodeGeoJSONWith(map[string]any{"coordinates": []float64{1, 2}}, geojson.WithPrettyCoordinates())
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"properties":{"coordinates":[1,2]}`),
+		it.String(string(data)).Contain(`"coordinates":[[24.9384, 60.1699], [24.9415, 60.1725, 12.3]]`),
+	)
+
+	gc := geojson.New(city_helsinki, &geojson.GeometryCollection{Geometries: []geojson.Geometry{
+		&geojson.Point{Coords: geojson.Coord{102, 0.5}},
+	}})
+	gc.Foreign = map[string]json.RawMessage{"coordinates": json.RawMessage(`[1,2]`)}
+	data, err = gc.EncodeGeoJSONWith(nil, geojson.WithPrettyCoordinates())
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"geometries":[{"type":"Point","coordinates":[102, 0.5]}]`),
+		it.String(string(data)).Contain(`"coordinates":[1,2]}`),
+	)
+}
+
+type Country struct {
+	geojson.Feature
+	IsoA3 string `json:"iso_a3"`
+}
+
+func (x Country) MarshalJSON() ([]byte, error) {
+	type tStruct Country
+	return x.Feature.EncodeGeoJSON(tStruct(x))
+}
+
+func (x *Country) UnmarshalJSON(b []byte) error {
+	type tStruct *Country
+	return x.Feature.DecodeGeoJSONWith(b, tStruct(x), geojson.WithIDFromProperty("iso_a3"))
+}
+
+func TestDecodeWithIDFromProperty(t *testing.T) {
+	var c geojson.Collection[Country]
+	err := json.Unmarshal([]byte(`{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "geometry": null, "properties": {"iso_a3": "FIN"}},
+			{"type": "Feature", "id": "[iso:swe]", "geometry": null, "properties": {"iso_a3": "SWE"}},
+			{"type": "Feature", "geometry": null, "properties": {"name": "Norway"}},
+			{"type": "Feature", "geometry": null}
+		]
+	}`), &c)
+
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(c.Features[0].ID, "FIN"),
+		it.Equal(c.Features[0].IsoA3, "FIN"),
+		it.Equal(c.Features[1].ID, "iso:swe"),
+		it.Equal(c.Features[1].IsoA3, "SWE"),
+		it.True(!c.Features[2].HasID()),
+		it.True(!c.Features[3].HasID()),
+	)
+
+	var fea geojson.Feature
+	it.Then(t).Should(
+		it.Nil(fea.DecodeGeoJSONWith([]byte(`{"type":"Feature","geometry":null,"properties":{"iso_a3":"FIN"}}`), nil, geojson.WithIDFromProperty("iso_a3"))),
+		it.Equal(fea.ID, "FIN"),
+		it.Equal(string(fea.Properties), `{"iso_a3":"FIN"}`),
+	)
+
+	it.Then(t).Should(
+		it.Nil(fea.DecodeGeoJSONWith([]byte(`{"type":"Feature","geometry":null,"properties":{"iso_n3":246}}`), nil, geojson.WithIDFromProperty("iso_n3"))),
+	)
+	num, ok := fea.NumericID()
+	it.Then(t).Should(
+		it.True(ok),
+		it.Equal(num, 246.0),
+	)
+}
+
+func TestEncodeWithIDExpansion(t *testing.T) {
+	prefixes := curie.Namespaces{"wikipedia": "https://en.wikipedia.org/wiki/"}
+
+	fea := geojson.NewPoint("wikipedia:Helsinki", geojson.Coord{24.9384, 60.1699})
+	data, err := fea.EncodeGeoJSONWith(nil, geojson.WithIDExpansion(prefixes))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"id":"https://en.wikipedia.org/wiki/Helsinki"`),
+	)
+
+	unknown := geojson.NewPoint("city:helsinki", geojson.Coord{24.9384, 60.1699})
+	data, err = unknown.EncodeGeoJSONWith(nil, geojson.WithIDExpansion(prefixes))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"id":"city:helsinki"`),
+	)
+
+	numeric := geojson.NewPoint("", geojson.Coord{24.9384, 60.1699})
+	numeric.SetNumericID(246)
+	data, err = numeric.EncodeGeoJSONWith(nil, geojson.WithIDExpansion(prefixes))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.String(string(data)).Contain(`"id":246`),
+	)
+}
+
+func TestDecodeWithIDCompaction(t *testing.T) {
+	prefixes := curie.Namespaces{"wikipedia": "https://en.wikipedia.org/wiki/"}
+
+	var fea geojson.Feature
+	it.Then(t).Should(
+		it.Nil(fea.DecodeGeoJSONWith([]byte(`{"type":"Feature","id":"https://en.wikipedia.org/wiki/Helsinki","geometry":null}`), nil, geojson.WithIDCompaction(prefixes))),
+		it.Equal(fea.ID, "wikipedia:Helsinki"),
+	)
+
+	it.Then(t).Should(
+		it.Nil(fea.DecodeGeoJSONWith([]byte(`{"type":"Feature","id":"[wikipedia:Stockholm]","geometry":null}`), nil, geojson.WithIDCompaction(prefixes))),
+		it.Equal(fea.ID, "wikipedia:Stockholm"),
+	)
+
+	it.Then(t).Should(
+		it.Nil(fea.DecodeGeoJSONWith([]byte(`{"type":"Feature","id":"https://example.com/Oslo","geometry":null}`), nil, geojson.WithIDCompaction(prefixes))),
+		it.Equal(fea.ID, "https://example.com/Oslo"),
+	)
+
+	it.Then(t).ShouldNot(
+		it.Nil(fea.DecodeGeoJSONWith([]byte(`{"type":"Feature","id":"https://en.wikipedia.org/wiki/Helsinki","geometry":null}`), nil)),
+	)
+
+	src := geojson.NewPoint("wikipedia:Helsinki", geojson.Coord{24.9384, 60.1699})
+	data, err := src.EncodeGeoJSONWith(nil, geojson.WithIDExpansion(prefixes))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Nil(fea.DecodeGeoJSONWith(data, nil, geojson.WithIDCompaction(prefixes))),
+		it.Equal(fea.ID, src.ID),
+	)
+}
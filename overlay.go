@@ -0,0 +1,497 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// The file implements boolean operations over polygons. Rings of both
+// operands are split at mutual intersections into edges, each edge is
+// classified against the other operand (inside, outside or shared boundary)
+// and edges selected by the operation are traced into rings of the result.
+// Edges are directed so that interior of polygon is at left.
+
+// overlaySnap is the distance (degrees) within which positions of
+// operands are considered equal, it is about 0.1 mm.
+const overlaySnap = 1e-9
+
+type overlayOp int
+
+const (
+	overlayUnion overlayOp = iota
+	overlayIntersection
+	overlayDifference
+)
+
+// UnionPolygons merges polygons into the single geometry, the overlapping
+// and adjacent polygons become one. It returns Polygon if the union is
+// connected, MultiPolygon otherwise, or nil if there are no polygons.
+// Polygons must be valid (see IsValid), the error describes violation.
+//
+// Positions within 1e-9 degrees are considered equal. The union is computed
+// at plane of lng, lat coordinates, positions of the result are two
+// dimensional and rings follow the right-hand rule.
+func UnionPolygons(polys ...*Polygon) (Geometry, error) {
+	var acc []Surface
+	for i, poly := range polys {
+		x, err := overlayOperand(i, poly)
+		if err != nil {
+			return nil, err
+		}
+
+		seq, err := overlay(acc, x, overlayUnion, overlaySnap)
+		if err != nil {
+			return nil, fmt.Errorf("polygon %d: %w", i, err)
+		}
+		acc = seq
+	}
+
+	return polygonal(acc), nil
+}
+
+// Difference returns the part of polygon a outside of polygon b, e.g. land
+// minus water. It returns Polygon, MultiPolygon if the result is disjoint,
+// or nil if b covers a. See UnionPolygons about requirements to polygons
+// and precision, the coincident edges of polygons snap within 1e-9 degrees.
+func Difference(a, b *Polygon) (Geometry, error) {
+	return overlayPolygons(a, b, overlayDifference)
+}
+
+// Intersection returns the part shared by polygons a and b. It returns
+// Polygon, MultiPolygon if the result is disjoint, or nil if polygons do
+// not overlap, touching polygons do not overlap. See UnionPolygons about
+// requirements to polygons and precision.
+func Intersection(a, b *Polygon) (Geometry, error) {
+	return overlayPolygons(a, b, overlayIntersection)
+}
+
+func overlayPolygons(a, b *Polygon, op overlayOp) (Geometry, error) {
+	x, err := overlayOperand(0, a)
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := overlayOperand(1, b)
+	if err != nil {
+		return nil, err
+	}
+
+	seq, err := overlay(x, y, op, overlaySnap)
+	if err != nil {
+		return nil, err
+	}
+
+	return polygonal(seq), nil
+}
+
+// operand of overlay, nil polygon is empty
+func overlayOperand(i int, poly *Polygon) ([]Surface, error) {
+	if poly == nil {
+		return nil, nil
+	}
+
+	if _, err := poly.IsValid(); err != nil {
+		return nil, fmt.Errorf("polygon %d: %w", i, err)
+	}
+
+	return []Surface{poly.Coords}, nil
+}
+
+// geometry of overlay result
+func polygonal(seq []Surface) Geometry {
+	switch len(seq) {
+	case 0:
+		return nil
+	case 1:
+		return &Polygon{Coords: seq[0]}
+	default:
+		return &MultiPolygon{Coords: Surfaces(seq)}
+	}
+}
+
+// overlayEdge is directed edge, interior of polygon is at left
+type overlayEdge struct{ a, b Coord }
+
+func (e overlayEdge) key() [4]float64 {
+	return [4]float64{e.a.Lng(), e.a.Lat(), e.b.Lng(), e.b.Lat()}
+}
+
+func (e overlayEdge) reverse() overlayEdge { return overlayEdge{a: e.b, b: e.a} }
+
+func (e overlayEdge) midpoint() Coord {
+	return Coord{(e.a.Lng() + e.b.Lng()) / 2, (e.a.Lat() + e.b.Lat()) / 2}
+}
+
+// overlayNodes snaps positions to nodes of overlay, the node is the first
+// position seen within snap distance.
+type overlayNodes struct {
+	eps  float64
+	grid map[[2]int64][]Coord
+}
+
+func (g *overlayNodes) snap(c Coord) Coord {
+	c = Coord{c.Lng(), c.Lat()}
+	if g.eps <= 0 {
+		return c
+	}
+
+	x, y := int64(math.Floor(c.Lng()/g.eps)), int64(math.Floor(c.Lat()/g.eps))
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for _, n := range g.grid[[2]int64{x + dx, y + dy}] {
+				if math.Hypot(n.Lng()-c.Lng(), n.Lat()-c.Lat()) <= g.eps {
+					return n
+				}
+			}
+		}
+	}
+
+	g.grid[[2]int64{x, y}] = append(g.grid[[2]int64{x, y}], c)
+	return c
+}
+
+// overlayRegion is polygonal operand of overlay, rings are snapped to nodes
+// and follow the right-hand rule.
+type overlayRegion struct {
+	surfaces []Surface
+	edges    []overlayEdge
+}
+
+func newOverlayRegion(seq []Surface, nodes *overlayNodes) overlayRegion {
+	region := overlayRegion{}
+	for _, surface := range seq {
+		rings := Surface{}
+		for r, ring := range surface {
+			x := Curve{}
+			for _, c := range ring {
+				if c = nodes.snap(c); len(x) == 0 || !x[len(x)-1].equal(c) {
+					x = append(x, c)
+				}
+			}
+
+			if len(x) > 1 && x[0].equal(x[len(x)-1]) {
+				x = x[:len(x)-1]
+			}
+			if len(x) < 3 {
+				continue
+			}
+
+			x = append(x, x[0])
+			if (r == 0) == (x.planarArea() < 0) {
+				x.reverse()
+			}
+			rings = append(rings, x)
+
+			for i := 1; i < len(x); i++ {
+				region.edges = append(region.edges, overlayEdge{a: x[i-1], b: x[i]})
+			}
+		}
+
+		if len(rings) > 0 {
+			region.surfaces = append(region.surfaces, rings)
+		}
+	}
+	return region
+}
+
+func (region overlayRegion) contains(c Coord) bool {
+	for _, surface := range region.surfaces {
+		if surface.contains(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// equal checks if positions are equal at plane of lng, lat
+func (c Coord) equal(x Coord) bool {
+	return c.Lng() == x.Lng() && c.Lat() == x.Lat()
+}
+
+// overlay computes boolean operation over regions a and b
+func overlay(a, b []Surface, op overlayOp, eps float64) ([]Surface, error) {
+	nodes := &overlayNodes{eps: eps, grid: map[[2]int64][]Coord{}}
+	ra, rb := newOverlayRegion(a, nodes), newOverlayRegion(b, nodes)
+
+	ea, eb := splitEdges(ra.edges, rb.edges, nodes)
+
+	shared := make(map[[4]float64]bool, len(eb))
+	for _, e := range eb {
+		shared[e.key()] = true
+	}
+
+	seq := []overlayEdge{}
+	for _, e := range ea {
+		switch {
+		case shared[e.key()]:
+			if op != overlayDifference {
+				seq = append(seq, e)
+			}
+		case shared[e.reverse().key()]:
+			if op == overlayDifference {
+				seq = append(seq, e)
+			}
+		case rb.contains(e.midpoint()):
+			if op == overlayIntersection {
+				seq = append(seq, e)
+			}
+		default:
+			if op != overlayIntersection {
+				seq = append(seq, e)
+			}
+		}
+	}
+
+	owned := make(map[[4]float64]bool, len(ea))
+	for _, e := range ea {
+		owned[e.key()] = true
+	}
+
+	for _, e := range eb {
+		switch {
+		case owned[e.key()] || owned[e.reverse().key()]:
+			// Note: shared boundary is selected from edges of a
+		case ra.contains(e.midpoint()):
+			switch op {
+			case overlayIntersection:
+				seq = append(seq, e)
+			case overlayDifference:
+				seq = append(seq, e.reverse())
+			}
+		default:
+			if op == overlayUnion {
+				seq = append(seq, e)
+			}
+		}
+	}
+
+	rings, err := traceRings(seq)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembleSurfaces(rings), nil
+}
+
+// splitEdges splits edges of both operands at mutual intersections
+func splitEdges(ea, eb []overlayEdge, nodes *overlayNodes) ([]overlayEdge, []overlayEdge) {
+	sa, sb := make([]Curve, len(ea)), make([]Curve, len(eb))
+
+	for i, x := range ea {
+		for k, y := range eb {
+			if !overlapsWithin(x, y, nodes.eps) {
+				continue
+			}
+
+			for _, c := range intersectSegments(x.a, x.b, y.a, y.b) {
+				c = nodes.snap(c)
+				sa[i] = append(sa[i], c)
+				sb[k] = append(sb[k], c)
+			}
+
+			// Note: vertices within snap distance of the edge split it
+			if planarDistance(y.a, x.a, x.b) <= nodes.eps {
+				sa[i] = append(sa[i], y.a)
+			}
+			if planarDistance(x.a, y.a, y.b) <= nodes.eps {
+				sb[k] = append(sb[k], x.a)
+			}
+		}
+	}
+
+	return splitAt(ea, sa), splitAt(eb, sb)
+}
+
+// overlapsWithin checks if bounding boxes of edges overlap within eps
+func overlapsWithin(x, y overlayEdge, eps float64) bool {
+	return min(x.a.Lng(), x.b.Lng()) <= max(y.a.Lng(), y.b.Lng())+eps &&
+		min(y.a.Lng(), y.b.Lng()) <= max(x.a.Lng(), x.b.Lng())+eps &&
+		min(x.a.Lat(), x.b.Lat()) <= max(y.a.Lat(), y.b.Lat())+eps &&
+		min(y.a.Lat(), y.b.Lat()) <= max(x.a.Lat(), x.b.Lat())+eps
+}
+
+func splitAt(edges []overlayEdge, splits []Curve) []overlayEdge {
+	seq := make([]overlayEdge, 0, len(edges))
+	for i, e := range edges {
+		if len(splits[i]) == 0 {
+			seq = append(seq, e)
+			continue
+		}
+
+		dx, dy := e.b.Lng()-e.a.Lng(), e.b.Lat()-e.a.Lat()
+		at := func(c Coord) float64 { return (c.Lng()-e.a.Lng())*dx + (c.Lat()-e.a.Lat())*dy }
+
+		pts := append(splits[i], e.b)
+		slices.SortStableFunc(pts, func(x, y Coord) int {
+			switch tx, ty := at(x), at(y); {
+			case tx < ty:
+				return -1
+			case tx > ty:
+				return 1
+			default:
+				return 0
+			}
+		})
+
+		a := e.a
+		for _, c := range pts {
+			if a.equal(c) || at(c) <= at(a) {
+				continue
+			}
+			seq = append(seq, overlayEdge{a: a, b: c})
+			a = c
+		}
+	}
+	return seq
+}
+
+// traceRings connects edges into closed rings, the walk turns leftmost at
+// nodes with multiple outgoing edges. Rings touching itself are split at
+// repeated nodes.
+func traceRings(seq []overlayEdge) ([]Curve, error) {
+	out := map[[2]float64][]int{}
+	for i, e := range seq {
+		k := [2]float64{e.a.Lng(), e.a.Lat()}
+		out[k] = append(out[k], i)
+	}
+
+	used := make([]bool, len(seq))
+	rings := []Curve{}
+	for i := range seq {
+		if used[i] {
+			continue
+		}
+
+		walk := Curve{seq[i].a}
+		for e := i; ; {
+			used[e] = true
+			walk = append(walk, seq[e].b)
+			if seq[e].b.equal(seq[i].a) {
+				break
+			}
+
+			next := -1
+			for _, k := range out[[2]float64{seq[e].b.Lng(), seq[e].b.Lat()}] {
+				if !used[k] && (next == -1 || turn(seq[e], seq[k]) > turn(seq[e], seq[next])) {
+					next = k
+				}
+			}
+
+			if next == -1 {
+				return nil, fmt.Errorf("%w: boundary is not closed at %v", ErrInvalidGeometry, seq[e].b)
+			}
+			e = next
+		}
+
+		rings = append(rings, splitLoops(walk)...)
+	}
+
+	return rings, nil
+}
+
+// turn angle from edge a to edge b, it is positive for left turn
+func turn(a, b overlayEdge) float64 {
+	ax, ay := a.b.Lng()-a.a.Lng(), a.b.Lat()-a.a.Lat()
+	bx, by := b.b.Lng()-b.a.Lng(), b.b.Lat()-b.a.Lat()
+	return math.Atan2(ax*by-ay*bx, ax*bx+ay*by)
+}
+
+// splitLoops splits the closed walk at repeated nodes into simple rings,
+// rings without area are dropped.
+func splitLoops(walk Curve) []Curve {
+	seq := []Curve{}
+	stack := Curve{}
+	index := map[[2]float64]int{}
+
+	for _, c := range walk[:len(walk)-1] {
+		k := [2]float64{c.Lng(), c.Lat()}
+		if at, has := index[k]; has {
+			seq = append(seq, append(slices.Clone(stack[at:]), c))
+			for _, x := range stack[at+1:] {
+				delete(index, [2]float64{x.Lng(), x.Lat()})
+			}
+			stack = stack[:at+1]
+			continue
+		}
+		index[k] = len(stack)
+		stack = append(stack, c)
+	}
+	seq = append(seq, append(stack, stack[0]))
+
+	rings := seq[:0]
+	for _, ring := range seq {
+		if len(ring) >= 4 && ring.planarArea() != 0 {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+// assembleSurfaces assigns holes (clockwise rings) to the smallest
+// exterior ring containing them.
+func assembleSurfaces(rings []Curve) []Surface {
+	seq := []Surface{}
+	holes := []Curve{}
+	for _, ring := range rings {
+		if ring.planarArea() > 0 {
+			seq = append(seq, Surface{ring})
+		} else {
+			holes = append(holes, ring)
+		}
+	}
+
+	for _, hole := range holes {
+		k, area := -1, math.Inf(1)
+		for i, surface := range seq {
+			if a := surface[0].planarArea(); a < area && encloses(surface[0], hole) {
+				k, area = i, a
+			}
+		}
+
+		if k != -1 {
+			seq[k] = append(seq[k], hole)
+		}
+	}
+
+	return seq
+}
+
+// encloses checks if the ring is inside of exterior, rings may touch
+func encloses(exterior, ring Curve) bool {
+	for i := 1; i < len(ring); i++ {
+		for _, c := range []Coord{ring[i], overlayEdge{a: ring[i-1], b: ring[i]}.midpoint()} {
+			switch exterior.ring(c) {
+			case +1:
+				return true
+			case -1:
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// polygons of polygonal geometry, other geometries have none
+func polygonsOf(geo Geometry) []*Polygon {
+	switch v := geo.(type) {
+	case *Polygon:
+		return []*Polygon{v}
+	case *MultiPolygon:
+		seq := make([]*Polygon, len(v.Coords))
+		for i, surface := range v.Coords {
+			seq[i] = &Polygon{Coords: surface}
+		}
+		return seq
+	default:
+		return nil
+	}
+}
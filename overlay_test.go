@@ -0,0 +1,346 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func square(lng0, lat0, lng1, lat1 float64) *geojson.Polygon {
+	return &geojson.Polygon{Coords: geojson.Surface{
+		{{lng0, lat0}, {lng1, lat0}, {lng1, lat1}, {lng0, lat1}, {lng0, lat0}},
+	}}
+}
+
+// planar area of polygonal geometry, holes are subtracted
+func planarArea(geo geojson.Geometry) float64 {
+	ring := func(seq geojson.Curve) float64 {
+		area := 0.0
+		for i := 1; i < len(seq); i++ {
+			area += seq[i-1][0]*seq[i][1] - seq[i][0]*seq[i-1][1]
+		}
+		return area / 2
+	}
+
+	surface := func(seq geojson.Surface) float64 {
+		area := 0.0
+		for _, x := range seq {
+			area += ring(x)
+		}
+		return area
+	}
+
+	switch v := geo.(type) {
+	case *geojson.Polygon:
+		return surface(v.Coords)
+	case *geojson.MultiPolygon:
+		area := 0.0
+		for _, x := range v.Coords {
+			area += surface(x)
+		}
+		return area
+	default:
+		return 0
+	}
+}
+
+func TestUnionPolygons(t *testing.T) {
+	t.Run("Overlap", func(t *testing.T) {
+		geo, err := geojson.UnionPolygons(square(0, 0, 2, 2), square(1, 1, 3, 3))
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(poly.Coords), 1),
+			it.Equal(planarArea(geo), 7.0),
+			it.True(poly.Contains(geojson.Coord{0.5, 0.5})),
+			it.True(poly.Contains(geojson.Coord{2.5, 2.5})),
+			it.True(!poly.Contains(geojson.Coord{2.5, 0.5})),
+			it.True(!poly.IsClockwise(0)),
+		)
+	})
+
+	t.Run("SharedEdge", func(t *testing.T) {
+		geo, err := geojson.UnionPolygons(square(0, 0, 1, 1), square(1, 0, 2, 1))
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(poly.Coords), 1),
+			it.Equal(planarArea(geo), 2.0),
+		)
+	})
+
+	t.Run("PartialSharedEdge", func(t *testing.T) {
+		geo, err := geojson.UnionPolygons(square(0, 0, 2, 2), square(2, 1, 3, 3))
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(poly.Coords), 1),
+			it.Equal(planarArea(geo), 6.0),
+		)
+	})
+
+	t.Run("Disjoint", func(t *testing.T) {
+		geo, err := geojson.UnionPolygons(square(0, 0, 1, 1), square(2, 2, 3, 3))
+		mpoly, ok := geo.(*geojson.MultiPolygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(mpoly.Coords), 2),
+			it.Equal(planarArea(geo), 2.0),
+		)
+	})
+
+	t.Run("TouchAtCorner", func(t *testing.T) {
+		geo, err := geojson.UnionPolygons(square(0, 0, 1, 1), square(1, 1, 2, 2))
+		mpoly, ok := geo.(*geojson.MultiPolygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(mpoly.Coords), 2),
+			it.Equal(len(mpoly.Coords[0][0]), 5),
+			it.Equal(len(mpoly.Coords[1][0]), 5),
+		)
+	})
+
+	t.Run("Within", func(t *testing.T) {
+		geo, err := geojson.UnionPolygons(square(0, 0, 4, 4), square(1, 1, 2, 2))
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(poly.Coords), 1),
+			it.Equal(planarArea(geo), 16.0),
+		)
+	})
+
+	t.Run("EnclosesHole", func(t *testing.T) {
+		u := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0}, {3.0, 0.0}, {3.0, 3.0}, {2.0, 3.0}, {2.0, 1.0}, {1.0, 1.0}, {1.0, 3.0}, {0.0, 3.0}, {0.0, 0.0}},
+		}}
+		geo, err := geojson.UnionPolygons(u, square(0, 2, 3, 4))
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(poly.Coords), 2),
+			it.Equal(planarArea(geo), 11.0),
+			it.True(!poly.Contains(geojson.Coord{1.5, 1.5})),
+			it.True(poly.IsClockwise(1)),
+		)
+	})
+
+	t.Run("FillsHole", func(t *testing.T) {
+		donut := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0}, {4.0, 0.0}, {4.0, 4.0}, {0.0, 4.0}, {0.0, 0.0}},
+			{{1.0, 1.0}, {1.0, 3.0}, {3.0, 3.0}, {3.0, 1.0}, {1.0, 1.0}},
+		}}
+		geo, err := geojson.UnionPolygons(donut, square(1, 1, 3, 3))
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(poly.Coords), 1),
+			it.Equal(planarArea(geo), 16.0),
+		)
+	})
+
+	t.Run("Many", func(t *testing.T) {
+		seq := []*geojson.Polygon{}
+		for i := 0; i < 5; i++ {
+			for k := 0; k < 5; k++ {
+				seq = append(seq, square(float64(i), float64(k), float64(i+1), float64(k+1)))
+			}
+		}
+		geo, err := geojson.UnionPolygons(seq...)
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(poly.Coords), 1),
+			it.Equal(planarArea(geo), 25.0),
+		)
+	})
+
+	t.Run("Single", func(t *testing.T) {
+		cw := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0}, {0.0, 1.0}, {1.0, 1.0}, {1.0, 0.0}, {0.0, 0.0}},
+		}}
+		geo, err := geojson.UnionPolygons(cw)
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.True(!poly.IsClockwise(0)),
+			it.True(cw.IsClockwise(0)),
+		)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		geo, err := geojson.UnionPolygons()
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equiv(geo, nil),
+		)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		bowtie := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0}, {1.0, 1.0}, {1.0, 0.0}, {0.0, 1.0}, {0.0, 0.0}},
+		}}
+		_, err := geojson.UnionPolygons(square(0, 0, 1, 1), bowtie)
+		it.Then(t).Should(
+			it.True(errors.Is(err, geojson.ErrInvalidRing)),
+			it.String(err.Error()).Contain("polygon 1"),
+		)
+	})
+}
+
+func TestIntersection(t *testing.T) {
+	t.Run("Overlap", func(t *testing.T) {
+		geo, err := geojson.Intersection(square(0, 0, 2, 2), square(1, 1, 3, 3))
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(poly.Coords), 1),
+			it.Equal(planarArea(geo), 1.0),
+			it.Equiv(poly.BoundingBox(), geojson.BoundingBox{1.0, 1.0, 2.0, 2.0}),
+		)
+	})
+
+	t.Run("Identical", func(t *testing.T) {
+		geo, err := geojson.Intersection(square(0, 0, 2, 2), square(0, 0, 2, 2))
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(planarArea(geo), 4.0),
+		)
+	})
+
+	t.Run("Disjoint", func(t *testing.T) {
+		geo, err := geojson.Intersection(square(0, 0, 1, 1), square(2, 2, 3, 3))
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equiv(geo, nil),
+		)
+	})
+
+	t.Run("SharedEdge", func(t *testing.T) {
+		geo, err := geojson.Intersection(square(0, 0, 1, 1), square(1, 0, 2, 1))
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equiv(geo, nil),
+		)
+	})
+
+	t.Run("Holes", func(t *testing.T) {
+		donut := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0}, {4.0, 0.0}, {4.0, 4.0}, {0.0, 4.0}, {0.0, 0.0}},
+			{{1.0, 1.0}, {1.0, 3.0}, {3.0, 3.0}, {3.0, 1.0}, {1.0, 1.0}},
+		}}
+		geo, err := geojson.Intersection(donut, square(-1, 1.5, 5, 2.5))
+		mpoly, ok := geo.(*geojson.MultiPolygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(mpoly.Coords), 2),
+			it.Equal(planarArea(geo), 2.0),
+		)
+	})
+}
+
+func TestDifference(t *testing.T) {
+	t.Run("Overlap", func(t *testing.T) {
+		geo, err := geojson.Difference(square(0, 0, 2, 2), square(1, 1, 3, 3))
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(poly.Coords), 1),
+			it.Equal(planarArea(geo), 3.0),
+			it.True(!poly.Contains(geojson.Coord{1.5, 1.5})),
+		)
+	})
+
+	t.Run("Hole", func(t *testing.T) {
+		geo, err := geojson.Difference(square(0, 0, 4, 4), square(1, 1, 3, 3))
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(poly.Coords), 2),
+			it.Equal(planarArea(geo), 12.0),
+			it.True(!poly.IsClockwise(0)),
+			it.True(poly.IsClockwise(1)),
+		)
+	})
+
+	t.Run("Split", func(t *testing.T) {
+		geo, err := geojson.Difference(square(0, 0, 3, 1), square(1, -1, 2, 2))
+		mpoly, ok := geo.(*geojson.MultiPolygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(mpoly.Coords), 2),
+			it.Equal(planarArea(geo), 2.0),
+		)
+	})
+
+	t.Run("CoincidentEdges", func(t *testing.T) {
+		geo, err := geojson.Difference(square(0, 0, 2, 1), square(1, 0, 2, 1))
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(poly.Coords), 1),
+			it.Equal(planarArea(geo), 1.0),
+		)
+	})
+
+	t.Run("SnapEdges", func(t *testing.T) {
+		geo, err := geojson.Difference(square(0, 0, 2, 1), square(1, -1e-12, 2+1e-12, 1))
+		poly, ok := geo.(*geojson.Polygon)
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+			it.Equal(len(poly.Coords), 1),
+			it.True(near(planarArea(geo), 1.0, 1e-9)),
+		)
+	})
+
+	t.Run("Covered", func(t *testing.T) {
+		geo, err := geojson.Difference(square(1, 1, 2, 2), square(0, 0, 3, 3))
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equiv(geo, nil),
+		)
+	})
+
+	t.Run("Disjoint", func(t *testing.T) {
+		geo, err := geojson.Difference(square(0, 0, 1, 1), square(2, 2, 3, 3))
+		it.Then(t).Should(
+			it.Nil(err),
+			it.Equal(planarArea(geo), 1.0),
+		)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := geojson.Difference(square(0, 0, 1, 1), &geojson.Polygon{})
+		it.Then(t).Should(
+			it.True(errors.Is(err, geojson.ErrInvalidRing)),
+			it.String(err.Error()).Contain("polygon 1"),
+		)
+	})
+}
@@ -0,0 +1,261 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import "math"
+
+// The file implements planar predicates over lng, lat coordinates.
+
+// onSegment checks if the position c lays on the segment a, b
+func onSegment(c, a, b Coord) bool {
+	lat, lng := c.LatLng()
+	alat, alng := a.LatLng()
+	blat, blng := b.LatLng()
+
+	cross := (blng-alng)*(lat-alat) - (blat-alat)*(lng-alng)
+	if cross != 0 {
+		return false
+	}
+
+	return min(alng, blng) <= lng && lng <= max(alng, blng) &&
+		min(alat, blat) <= lat && lat <= max(alat, blat)
+}
+
+// ring classifies position against the linear ring, it returns
+// -1 if position is outside, 0 if it lays on the boundary and
+// +1 if position is inside.
+func (seq Curve) ring(c Coord) int {
+	lat, lng := c.LatLng()
+	inside := false
+
+	for i, j := 0, len(seq)-1; i < len(seq); j, i = i, i+1 {
+		a, b := seq[j], seq[i]
+		if onSegment(c, a, b) {
+			return 0
+		}
+
+		alat, alng := a.LatLng()
+		blat, blng := b.LatLng()
+		if (alat > lat) != (blat > lat) &&
+			lng < (blng-alng)*(lat-alat)/(blat-alat)+alng {
+			inside = !inside
+		}
+	}
+
+	if inside {
+		return +1
+	}
+	return -1
+}
+
+// contains checks if position is within polygon rings
+func (seq Surface) contains(c Coord) bool {
+	if len(seq) == 0 || len(seq[0]) < 4 || len(c) < 2 {
+		return false
+	}
+
+	switch seq[0].ring(c) {
+	case -1:
+		return false
+	case 0:
+		return true
+	}
+
+	for _, hole := range seq[1:] {
+		if hole.ring(c) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Contains checks if the position is within the polygon using ray-casting.
+// A position within the hole is not contained by polygon. Positions on
+// the boundary (edges and vertices of exterior and interior rings) are
+// contained by polygon.
+func (geo *Polygon) Contains(c Coord) bool { return geo.Coords.contains(c) }
+
+// Contains checks if the position is within any of polygons,
+// see Polygon.Contains for details.
+func (geo *MultiPolygon) Contains(c Coord) bool {
+	for _, surface := range geo.Coords {
+		if surface.contains(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// planarArea is the signed area of the ring at plane,
+// it is positive for counter-clockwise ring.
+func (seq Curve) planarArea() float64 {
+	area := 0.0
+	for i := 1; i < len(seq); i++ {
+		p, q := seq[i-1], seq[i]
+		area += p.Lng()*q.Lat() - q.Lng()*p.Lat()
+	}
+	return area / 2
+}
+
+// reverse order of ring positions in place
+func (seq Curve) reverse() {
+	for i, j := 0, len(seq)-1; i < j; i, j = i+1, j-1 {
+		seq[i], seq[j] = seq[j], seq[i]
+	}
+}
+
+// rewind rings of surface to follow right-hand rule
+func (seq Surface) rewind() {
+	for i, ring := range seq {
+		if (i == 0) == (ring.planarArea() < 0) {
+			ring.reverse()
+		}
+	}
+}
+
+// IsClockwise checks the winding order of the ring, the exterior ring
+// has index 0, holes follow it. It returns false if ring does not exist.
+func (geo *Polygon) IsClockwise(ring int) bool {
+	if ring < 0 || ring >= len(geo.Coords) {
+		return false
+	}
+	return geo.Coords[ring].planarArea() < 0
+}
+
+// Rewind reorders positions of the polygon in place to satisfy the
+// right-hand rule (RFC 7946, section 3.1.6): exterior ring is
+// counter-clockwise, and holes are clockwise.
+func (geo *Polygon) Rewind() *Polygon {
+	geo.Coords.rewind()
+	return geo
+}
+
+// Rewind reorders positions of each polygon in place,
+// see Polygon.Rewind for details.
+func (geo *MultiPolygon) Rewind() *MultiPolygon {
+	for _, surface := range geo.Coords {
+		surface.rewind()
+	}
+	return geo
+}
+
+// orientation of the triplet of positions, it is positive for
+// counter-clockwise turn, negative for clockwise and 0 for collinear.
+func orientation(a, b, c Coord) float64 {
+	return (b.Lng()-a.Lng())*(c.Lat()-a.Lat()) - (b.Lat()-a.Lat())*(c.Lng()-a.Lng())
+}
+
+func sign(x float64) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// intersectSegments returns intersection of segments a, b and c, d. It is
+// either empty, single position or endpoints of collinear overlap.
+func intersectSegments(a, b, c, d Coord) Curve {
+	o1, o2 := sign(orientation(a, b, c)), sign(orientation(a, b, d))
+	o3, o4 := sign(orientation(c, d, a)), sign(orientation(c, d, b))
+
+	if o1 == 0 && o2 == 0 {
+		return overlapSegments(a, b, c, d)
+	}
+
+	if o1*o2 > 0 || o3*o4 > 0 {
+		return nil
+	}
+
+	// the segments intersect at single position
+	dx, dy := b.Lng()-a.Lng(), b.Lat()-a.Lat()
+	ex, ey := d.Lng()-c.Lng(), d.Lat()-c.Lat()
+	t := ((c.Lng()-a.Lng())*ey - (c.Lat()-a.Lat())*ex) / (dx*ey - dy*ex)
+
+	return Curve{Coord{a.Lng() + t*dx, a.Lat() + t*dy}}
+}
+
+// overlapSegments returns endpoints of overlap of collinear segments
+func overlapSegments(a, b, c, d Coord) Curve {
+	// parametrize positions along dominant axis of segment a, b
+	axis := 0
+	if math.Abs(b.Lat()-a.Lat()) > math.Abs(b.Lng()-a.Lng()) {
+		axis = 1
+	}
+
+	lo, hi := a, b
+	if lo[axis] > hi[axis] {
+		lo, hi = hi, lo
+	}
+	if c[axis] > d[axis] {
+		c, d = d, c
+	}
+
+	if c[axis] > lo[axis] {
+		lo = c
+	}
+	if d[axis] < hi[axis] {
+		hi = d
+	}
+
+	switch {
+	case lo[axis] > hi[axis]:
+		return nil
+	case lo[axis] == hi[axis]:
+		return Curve{Coord{lo.Lng(), lo.Lat()}}
+	default:
+		return Curve{Coord{lo.Lng(), lo.Lat()}, Coord{hi.Lng(), hi.Lat()}}
+	}
+}
+
+// Intersects checks if line strings cross or touch each other.
+//
+// Note: positions are compared at plane of lng, lat coordinates, it is an
+// approximation that becomes inaccurate near the poles.
+func (geo *LineString) Intersects(other *LineString) bool {
+	for i := 1; i < len(geo.Coords); i++ {
+		for k := 1; k < len(other.Coords); k++ {
+			if len(intersectSegments(geo.Coords[i-1], geo.Coords[i], other.Coords[k-1], other.Coords[k])) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Intersections returns all positions where line strings cross or touch
+// each other. The collinear overlap of segments is reported by endpoints
+// of the overlap. See Intersects for the notes about accuracy.
+func (geo *LineString) Intersections(other *LineString) Curve {
+	seq := Curve{}
+	for i := 1; i < len(geo.Coords); i++ {
+		for k := 1; k < len(other.Coords); k++ {
+			for _, c := range intersectSegments(geo.Coords[i-1], geo.Coords[i], other.Coords[k-1], other.Coords[k]) {
+				if !seq.has(c) {
+					seq = append(seq, c)
+				}
+			}
+		}
+	}
+	return seq
+}
+
+// has checks if curve contains the position
+func (seq Curve) has(c Coord) bool {
+	for _, x := range seq {
+		if x.Lng() == c.Lng() && x.Lat() == c.Lat() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestPolygonContains(t *testing.T) {
+	geo := geojson.Polygon{Coords: coordPolygonWithHole}
+
+	it.Then(t).Should(
+		it.True(geo.Contains(geojson.Coord{100.1, 0.1})),
+		it.True(geo.Contains(geojson.Coord{100.9, 0.5})),
+		it.True(geo.Contains(geojson.Coord{100.0, 0.5})),
+		it.True(geo.Contains(geojson.Coord{101.0, 1.0})),
+		it.True(geo.Contains(geojson.Coord{100.2, 0.5})),
+	).ShouldNot(
+		it.True(geo.Contains(geojson.Coord{100.5, 0.5})),
+		it.True(geo.Contains(geojson.Coord{99.0, 0.5})),
+		it.True(geo.Contains(geojson.Coord{100.5, 1.5})),
+		it.True(geo.Contains(geojson.Coord{})),
+		it.True((&geojson.Polygon{}).Contains(geojson.Coord{100.1, 0.1})),
+	)
+}
+
+func TestMultiPolygonContains(t *testing.T) {
+	geo := geojson.MultiPolygon{Coords: coordMultiPolygon}
+
+	it.Then(t).Should(
+		it.True(geo.Contains(geojson.Coord{102.5, 2.5})),
+		it.True(geo.Contains(geojson.Coord{100.1, 0.1})),
+	).ShouldNot(
+		it.True(geo.Contains(geojson.Coord{100.5, 0.5})),
+		it.True(geo.Contains(geojson.Coord{101.5, 1.5})),
+	)
+}
+
+func TestPolygonRewind(t *testing.T) {
+	geo := geojson.Polygon{
+		Coords: geojson.Surface{
+			{{0, 0}, {0, 4}, {4, 4}, {4, 0}, {0, 0}},
+			{{1, 1}, {2, 1}, {2, 2}, {1, 2}, {1, 1}},
+		},
+	}
+
+	it.Then(t).Should(
+		it.True(geo.IsClockwise(0)),
+		it.True(!geo.IsClockwise(1)),
+		it.True(!geo.IsClockwise(2)),
+	)
+
+	geo.Rewind()
+
+	it.Then(t).Should(
+		it.True(!geo.IsClockwise(0)),
+		it.True(geo.IsClockwise(1)),
+		it.Equiv(geo.Coords[0], geojson.Curve{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}),
+		it.Equiv(geo.Coords[1], geojson.Curve{{1, 1}, {1, 2}, {2, 2}, {2, 1}, {1, 1}}),
+	)
+
+	geo.Rewind()
+
+	it.Then(t).Should(
+		it.Equiv(geo.Coords[0], geojson.Curve{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}),
+	)
+}
+
+func TestMultiPolygonRewind(t *testing.T) {
+	geo := geojson.MultiPolygon{
+		Coords: geojson.Surfaces{
+			{{{0, 0}, {0, 4}, {4, 4}, {4, 0}, {0, 0}}},
+			{{{10, 0}, {14, 0}, {14, 4}, {10, 4}, {10, 0}}},
+		},
+	}
+
+	geo.Rewind()
+
+	it.Then(t).Should(
+		it.Equiv(geo.Coords[0][0], geojson.Curve{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}),
+		it.Equiv(geo.Coords[1][0], geojson.Curve{{10, 0}, {14, 0}, {14, 4}, {10, 4}, {10, 0}}),
+	)
+}
+
+func TestLineStringIntersects(t *testing.T) {
+	a := &geojson.LineString{Coords: geojson.Curve{{0, 0}, {2, 2}, {4, 0}}}
+	b := &geojson.LineString{Coords: geojson.Curve{{0, 1}, {4, 1}}}
+	c := &geojson.LineString{Coords: geojson.Curve{{0, 3}, {4, 3}}}
+	d := &geojson.LineString{Coords: geojson.Curve{{1, 1}, {3, 3}}}
+	e := &geojson.LineString{Coords: geojson.Curve{{2, 2}, {2, 5}}}
+
+	it.Then(t).Should(
+		it.True(a.Intersects(b)),
+		it.True(a.Intersects(d)),
+		it.True(a.Intersects(e)),
+		it.True(!a.Intersects(c)),
+		it.True(!a.Intersects(&geojson.LineString{})),
+
+		it.Equiv(a.Intersections(b), geojson.Curve{{1, 1}, {3, 1}}),
+		it.Equiv(a.Intersections(c), geojson.Curve{}),
+		it.Equiv(a.Intersections(d), geojson.Curve{{1, 1}, {2, 2}}),
+		it.Equiv(a.Intersections(e), geojson.Curve{{2, 2}}),
+	)
+}
@@ -8,9 +8,18 @@
 
 package geojson
 
+import (
+	"math"
+
+	"github.com/fogfish/curie/v2"
+)
+
 // All position types implements shape interface,
 // allowing map function over coordinates.
-type Shape interface{ FMap(func(Coord)) }
+type Shape interface {
+	FMap(func(Coord))
+	FMapErr(func(Coord) error) error
+}
 
 // Coord is the fundamental geometry construct.
 // A position is an array of numbers that defines projected
@@ -18,17 +27,69 @@ type Shape interface{ FMap(func(Coord)) }
 // at plane in x, y order; easting, northing for projected
 // coordinates, longitude, and latitude for geographic coordinates.
 //
+// The optional third and fourth elements are altitude and measure. Other
+// elements are not supported, geometry decoder rejects such positions.
+//
 // One Position in the case of a Point geometry (0-dimensional point)
 type Coord []float64
 
+// FromLatLng creates position from latitude and longitude given in natural
+// order, the position keeps them in GeoJSON order: lng, lat.
+func FromLatLng(lat, lng float64) Coord { return Coord{lng, lat} }
+
 // LatLng coordinates of the position
 func (coords Coord) LatLng() (float64, float64) { return coords[1], coords[0] }
 func (coords Coord) Lat() float64               { return coords[1] }
 func (coords Coord) Lng() float64               { return coords[0] }
 
+// Alt is the elevation of the position, the optional third element.
+// It returns 0 if position has no elevation, use HasAlt to distinguish it.
+func (coords Coord) Alt() float64 {
+	if len(coords) < 3 {
+		return 0
+	}
+	return coords[2]
+}
+
+// HasAlt checks if the position defines elevation
+func (coords Coord) HasAlt() bool { return len(coords) >= 3 }
+
+// M is the measure of the position, the optional fourth element.
+func (coords Coord) M() (float64, bool) {
+	if len(coords) < 4 {
+		return 0, false
+	}
+	return coords[3], true
+}
+
+// Flip returns new position with swapped first two elements,
+// it fixes positions given in lat, lng order. Elevation is untouched.
+func (coords Coord) Flip() Coord {
+	c := make(Coord, len(coords))
+	copy(c, coords)
+	if len(c) >= 2 {
+		c[0], c[1] = c[1], c[0]
+	}
+	return c
+}
+
+// ValidWGS84 checks if position is within range of geographic
+// coordinates: longitude ∈ [-180, 180] and latitude ∈ [-90, 90].
+func (coords Coord) ValidWGS84() bool {
+	if len(coords) < 2 {
+		return false
+	}
+
+	lat, lng := coords.LatLng()
+	return -180.0 <= lng && lng <= 180.0 && -90.0 <= lat && lat <= 90.0
+}
+
 // FMap applies a function to each coords pair
 func (coords Coord) FMap(f func(Coord)) { f(coords) }
 
+// FMapErr applies a function to each coords pair, stops at first error
+func (coords Coord) FMapErr(f func(Coord) error) error { return f(coords) }
+
 // Sequence of positions in the case of a LineString
 // or MultiPoint geometry (1-dimensional curve)
 type Curve []Coord
@@ -40,6 +101,16 @@ func (seq Curve) FMap(f func(Coord)) {
 	}
 }
 
+// FMapErr applies a function to each coords pair, stops at first error
+func (seq Curve) FMapErr(f func(Coord) error) error {
+	for _, x := range seq {
+		if err := f(x); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Surface is an array of LineString or linear ring coordinates
 // in the case of a Polygon or MultiLineString geometry
 // (2-dimensional surface)
@@ -52,6 +123,16 @@ func (seq Surface) FMap(f func(Coord)) {
 	}
 }
 
+// FMapErr applies a function to each coords pair, stops at first error
+func (seq Surface) FMapErr(f func(Coord) error) error {
+	for _, x := range seq {
+		if err := x.FMapErr(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type Surfaces []Surface
 
 // FMap applies a function to each coords pair
@@ -61,6 +142,37 @@ func (seq Surfaces) FMap(f func(Coord)) {
 	}
 }
 
+// FMapErr applies a function to each coords pair, stops at first error
+func (seq Surfaces) FMapErr(f func(Coord) error) error {
+	for _, x := range seq {
+		if err := x.FMapErr(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shapes is a heterogeneous sequence of positions
+// in the case of a GeometryCollection
+type Shapes []Shape
+
+// FMap applies a function to each coords pair
+func (seq Shapes) FMap(f func(Coord)) {
+	for _, x := range seq {
+		x.FMap(f)
+	}
+}
+
+// FMapErr applies a function to each coords pair, stops at first error
+func (seq Shapes) FMapErr(f func(Coord) error) error {
+	for _, x := range seq {
+		if err := x.FMapErr(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Bounding Box: The value of the bbox member MUST be an array of
 // length 2*n where n is the number of dimensions represented in the
 // contained geometries, with all axes of the most southwesterly point
@@ -79,11 +191,40 @@ func (bbox BoundingBox) NorthEast() Coord {
 	return Coord(bbox[n:])
 }
 
-func (bbox BoundingBox) Join(box BoundingBox) {
+// Join extends bounding box to contain the given one, it mutates the
+// receiver and returns it. The result carries elevation axis if any of
+// boxes carries it, the axis spans elevation of 3D boxes only, consistently
+// with the box of geometry with mixed 2D and 3D positions. The copy of the
+// given box is returned if the receiver is empty, the receiver is returned
+// as-is if the given box is empty.
+//
+//	bbox = bbox.Join(box)
+func (bbox BoundingBox) Join(box BoundingBox) BoundingBox {
+	if len(box) < 4 {
+		return bbox
+	}
+
+	if len(bbox) < 4 {
+		return append(BoundingBox(nil), box...)
+	}
+
+	if len(bbox) < 6 && len(box) >= 6 {
+		bbox = BoundingBox{bbox[0], bbox[1], box[2], bbox[2], bbox[3], box[5]}
+	}
+
 	n := len(bbox) / 2
 	sw := box.SouthWest()
 	ne := box.NorthEast()
 
+	if n == 3 && sw.HasAlt() {
+		if bbox[2] > sw.Alt() {
+			bbox[2] = sw.Alt()
+		}
+		if bbox[n+2] < ne.Alt() {
+			bbox[n+2] = ne.Alt()
+		}
+	}
+
 	if bbox[0] > sw.Lng() {
 		bbox[0] = sw.Lng()
 	}
@@ -97,14 +238,201 @@ func (bbox BoundingBox) Join(box BoundingBox) {
 	if bbox[n+1] < ne.Lat() {
 		bbox[n+1] = ne.Lat()
 	}
+
+	return bbox
+}
+
+// Union returns new bounding box containing both boxes, it does not
+// mutate either of them. See Join for handling of the elevation axis.
+func (bbox BoundingBox) Union(box BoundingBox) BoundingBox {
+	if len(bbox) < 4 {
+		return BoundingBox(nil).Join(box)
+	}
+
+	return append(BoundingBox(nil), bbox...).Join(box)
+}
+
+// Equal checks if bounding boxes have the same elements. Boxes of different
+// dimensions are not equal, the empty boxes are equal.
+func (bbox BoundingBox) Equal(other BoundingBox) bool {
+	return bbox.EqualWithin(other, 0)
+}
+
+// EqualWithin checks if elements of bounding boxes differ at most by epsilon.
+// Boxes of different dimensions are not equal, the empty boxes are equal.
+func (bbox BoundingBox) EqualWithin(other BoundingBox, epsilon float64) bool {
+	if len(bbox) != len(other) {
+		return false
+	}
+
+	for i, x := range bbox {
+		if x == other[i] {
+			continue
+		}
+		// Note: NaN is never within epsilon
+		if d := math.Abs(x - other[i]); !(d <= epsilon) {
+			return false
+		}
+	}
+	return true
+}
+
+// UnionBoundingBox returns the bounding box containing all geometries.
+// Nil geometries and geometries without positions are skipped, the box
+// is seeded from the first located geometry. It returns nil if none of
+// geometries is located.
+func UnionBoundingBox(geos ...Geometry) BoundingBox {
+	var bbox BoundingBox
+	for _, geo := range geos {
+		if geo == nil {
+			continue
+		}
+		bbox = bbox.Join(geo.BoundingBox())
+	}
+
+	return bbox
+}
+
+// Contains checks if the position is within bounding box, inclusive on
+// the boundary. Only horizontal axes are compared.
+func (bbox BoundingBox) Contains(c Coord) bool {
+	if len(bbox) < 4 || len(c) < 2 {
+		return false
+	}
+
+	sw, ne := bbox.SouthWest(), bbox.NorthEast()
+	return sw.Lng() <= c.Lng() && c.Lng() <= ne.Lng() &&
+		sw.Lat() <= c.Lat() && c.Lat() <= ne.Lat()
+}
+
+// Intersects checks if bounding boxes overlap, boxes touching edges are
+// intersected. Only horizontal axes are compared.
+func (bbox BoundingBox) Intersects(box BoundingBox) bool {
+	if len(bbox) < 4 || len(box) < 4 {
+		return false
+	}
+
+	asw, ane := bbox.SouthWest(), bbox.NorthEast()
+	bsw, bne := box.SouthWest(), box.NorthEast()
+	return asw.Lng() <= bne.Lng() && bsw.Lng() <= ane.Lng() &&
+		asw.Lat() <= bne.Lat() && bsw.Lat() <= ane.Lat()
+}
+
+// Intersection returns the overlap of bounding boxes or nil if they do not
+// intersect. Consistently with Intersects, boxes touching edges or corners
+// are intersected, their overlap is a degenerated box of zero area. Only
+// horizontal axes are compared, the result is 2D box.
+func (bbox BoundingBox) Intersection(box BoundingBox) BoundingBox {
+	if !bbox.Intersects(box) {
+		return nil
+	}
+
+	asw, ane := bbox.SouthWest(), bbox.NorthEast()
+	bsw, bne := box.SouthWest(), box.NorthEast()
+	return BoundingBox{
+		max(asw.Lng(), bsw.Lng()),
+		max(asw.Lat(), bsw.Lat()),
+		min(ane.Lng(), bne.Lng()),
+		min(ane.Lat(), bne.Lat()),
+	}
 }
 
-// Helper function to build bounding box
+// Center of the bounding box, the midpoint of each axis
+func (bbox BoundingBox) Center() Coord {
+	n := len(bbox) / 2
+	if n == 0 {
+		return Coord{}
+	}
+
+	c := make(Coord, n)
+	for i := 0; i < n; i++ {
+		c[i] = (bbox[i] + bbox[n+i]) / 2
+	}
+	return c
+}
+
+// Expand returns new bounding box grown by margin degrees at horizontal
+// axes, the receiver is not mutated.
+func (bbox BoundingBox) Expand(margin float64) BoundingBox {
+	n := len(bbox) / 2
+	if n < 2 {
+		return nil
+	}
+
+	box := make(BoundingBox, len(bbox))
+	copy(box, bbox)
+	box[0], box[1] = box[0]-margin, box[1]-margin
+	box[n], box[n+1] = box[n]+margin, box[n+1]+margin
+	return box
+}
+
+// Width of bounding box in degrees of longitude
+func (bbox BoundingBox) Width() float64 {
+	if len(bbox) < 4 {
+		return 0
+	}
+	return bbox.NorthEast().Lng() - bbox.SouthWest().Lng()
+}
+
+// Height of bounding box in degrees of latitude
+func (bbox BoundingBox) Height() float64 {
+	if len(bbox) < 4 {
+		return 0
+	}
+	return bbox.NorthEast().Lat() - bbox.SouthWest().Lat()
+}
+
+// Polygon covering bounding box, it is a closed counter-clockwise ring
+// SW, SE, NE, NW, SW. The elevation axis is ignored. It returns nil for
+// empty bounding box.
+func (bbox BoundingBox) Polygon() *Polygon {
+	if len(bbox) < 4 {
+		return nil
+	}
+
+	sw, ne := bbox.SouthWest(), bbox.NorthEast()
+	return &Polygon{
+		Coords: Surface{
+			{
+				{sw.Lng(), sw.Lat()},
+				{ne.Lng(), sw.Lat()},
+				{ne.Lng(), ne.Lat()},
+				{sw.Lng(), ne.Lat()},
+				{sw.Lng(), sw.Lat()},
+			},
+		},
+	}
+}
+
+// Feature with polygon covering bounding box, see Polygon. The feature has
+// no geometry if bounding box is empty.
+func (bbox BoundingBox) Feature(id curie.IRI) Feature {
+	if geo := bbox.Polygon(); geo != nil {
+		return New(id, geo)
+	}
+
+	return Feature{ID: id}
+}
+
+// Helper function to build bounding box. The box carries elevation axis
+// if any of positions has three values, the measure is ignored.
 func boundingBox(seed Coord, coords interface{ FMap(f func(Coord)) }) BoundingBox {
 	s, w := seed.LatLng()
 	n, e := seed.LatLng()
+	lo, hi, alt := seed.Alt(), seed.Alt(), seed.HasAlt()
 
 	coords.FMap(func(c Coord) {
+		if c.HasAlt() {
+			switch a := c.Alt(); {
+			case !alt:
+				lo, hi, alt = a, a, true
+			case a < lo:
+				lo = a
+			case a > hi:
+				hi = a
+			}
+		}
+
 		lat, lng := c.LatLng()
 		if lng < w {
 			w = lng
@@ -121,5 +449,9 @@ func boundingBox(seed Coord, coords interface{ FMap(f func(Coord)) }) BoundingBo
 		}
 	})
 
+	if alt {
+		return BoundingBox{w, s, lo, e, n, hi}
+	}
+
 	return BoundingBox{w, s, e, n}
 }
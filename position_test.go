@@ -9,6 +9,7 @@
 package geojson_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/fogfish/geojson"
@@ -34,6 +35,18 @@ func TestPosition(t *testing.T) {
 	)
 }
 
+func TestFromLatLng(t *testing.T) {
+	p := geojson.FromLatLng(60.1699, 24.9384)
+	fea := geojson.NewPointLatLng("city:helsinki", 60.1699, 24.9384)
+
+	it.Then(t).Should(
+		it.Seq(p).Equal(24.9384, 60.1699),
+		it.Equal(p.Lat(), 60.1699),
+		it.Equal(p.Lng(), 24.9384),
+		it.Equiv(fea, geojson.NewPoint("city:helsinki", geojson.Coord{24.9384, 60.1699})),
+	)
+}
+
 func TestSequence(t *testing.T) {
 	p := geojson.Curve{
 		{100.0, 0.0},
@@ -107,3 +120,336 @@ func TestBBox(t *testing.T) {
 		it.Equal(bbox.NorthEast().Lat(), +20.0),
 	)
 }
+
+func TestPositionAlt(t *testing.T) {
+	p2 := geojson.Coord{100.0, 0.0}
+	p3 := geojson.Coord{100.0, 0.0, 10.0}
+
+	it.Then(t).Should(
+		it.Equal(p2.HasAlt(), false),
+		it.Equal(p2.Alt(), 0.0),
+		it.Equal(p3.HasAlt(), true),
+		it.Equal(p3.Alt(), 10.0),
+	)
+}
+
+func TestBBoxAlt(t *testing.T) {
+	bbox := geojson.BoundingBox{-10.0, -20.0, -5.0, +10.0, +20.0, +5.0}
+	bbox.Join(geojson.BoundingBox{-11.0, -21.0, -6.0, +11.0, +21.0, +6.0})
+
+	it.Then(t).Should(
+		it.Seq(bbox.SouthWest()).Equal(-11.0, -21.0, -6.0),
+		it.Seq(bbox.NorthEast()).Equal(+11.0, +21.0, +6.0),
+	)
+}
+
+func TestBBoxContains(t *testing.T) {
+	bbox := geojson.BoundingBox{-10.0, -20.0, +10.0, +20.0}
+	bbox3 := geojson.BoundingBox{-10.0, -20.0, 0.0, +10.0, +20.0, 100.0}
+
+	it.Then(t).Should(
+		it.True(bbox.Contains(geojson.Coord{0.0, 0.0})),
+		it.True(bbox.Contains(geojson.Coord{-10.0, 20.0})),
+		it.True(bbox.Contains(geojson.Coord{0.0, 0.0, 1000.0})),
+		it.True(bbox3.Contains(geojson.Coord{10.0, -20.0})),
+	).ShouldNot(
+		it.True(bbox.Contains(geojson.Coord{11.0, 0.0})),
+		it.True(bbox.Contains(geojson.Coord{0.0, -21.0})),
+		it.True(bbox.Contains(geojson.Coord{})),
+		it.True(geojson.BoundingBox(nil).Contains(geojson.Coord{0.0, 0.0})),
+	)
+}
+
+func TestBBoxIntersects(t *testing.T) {
+	bbox := geojson.BoundingBox{-10.0, -20.0, +10.0, +20.0}
+
+	it.Then(t).Should(
+		it.True(bbox.Intersects(geojson.BoundingBox{0.0, 0.0, 30.0, 30.0})),
+		it.True(bbox.Intersects(geojson.BoundingBox{-1.0, -1.0, 1.0, 1.0})),
+		it.True(bbox.Intersects(geojson.BoundingBox{-30.0, -30.0, 30.0, 30.0})),
+		it.True(bbox.Intersects(geojson.BoundingBox{10.0, 20.0, 30.0, 30.0})),
+		it.True(bbox.Intersects(geojson.BoundingBox{10.0, 20.0, 0.0, 30.0, 30.0, 10.0})),
+	).ShouldNot(
+		it.True(bbox.Intersects(geojson.BoundingBox{11.0, 0.0, 30.0, 30.0})),
+		it.True(bbox.Intersects(geojson.BoundingBox{-30.0, -30.0, -20.0, -25.0})),
+		it.True(bbox.Intersects(nil)),
+	)
+}
+
+func TestBBoxIntersection(t *testing.T) {
+	bbox := geojson.BoundingBox{-10.0, -20.0, +10.0, +20.0}
+
+	it.Then(t).Should(
+		it.Seq(bbox.Intersection(geojson.BoundingBox{0.0, 0.0, 30.0, 30.0})).Equal(0.0, 0.0, 10.0, 20.0),
+		it.Seq(bbox.Intersection(geojson.BoundingBox{-1.0, -1.0, 1.0, 1.0})).Equal(-1.0, -1.0, 1.0, 1.0),
+		it.Seq(bbox.Intersection(geojson.BoundingBox{-30.0, -30.0, 30.0, 30.0})).Equal(-10.0, -20.0, 10.0, 20.0),
+		it.Seq(bbox.Intersection(geojson.BoundingBox{10.0, 0.0, 30.0, 30.0})).Equal(10.0, 0.0, 10.0, 20.0),
+		it.Seq(bbox.Intersection(geojson.BoundingBox{10.0, 20.0, 30.0, 30.0})).Equal(10.0, 20.0, 10.0, 20.0),
+		it.Seq(bbox.Intersection(geojson.BoundingBox{0.0, 0.0, -5.0, 30.0, 30.0, 5.0})).Equal(0.0, 0.0, 10.0, 20.0),
+		it.Equiv(bbox.Intersection(geojson.BoundingBox{11.0, 0.0, 30.0, 30.0}), nil),
+		it.Equiv(bbox.Intersection(nil), nil),
+		it.Equiv(geojson.BoundingBox(nil).Intersection(bbox), nil),
+	)
+}
+
+func TestBBoxCenter(t *testing.T) {
+	bbox := geojson.BoundingBox{-10.0, -20.0, +20.0, +40.0}
+	bbox3 := geojson.BoundingBox{-10.0, -20.0, 0.0, +20.0, +40.0, 100.0}
+
+	it.Then(t).Should(
+		it.Seq(bbox.Center()).Equal(5.0, 10.0),
+		it.Seq(bbox3.Center()).Equal(5.0, 10.0, 50.0),
+		it.Equal(len(geojson.BoundingBox(nil).Center()), 0),
+	)
+}
+
+func TestBBoxExpand(t *testing.T) {
+	bbox := geojson.BoundingBox{-10.0, -20.0, +20.0, +40.0}
+	bbox3 := geojson.BoundingBox{-10.0, -20.0, 0.0, +20.0, +40.0, 100.0}
+
+	it.Then(t).Should(
+		it.Seq(bbox.Expand(1.0)).Equal(-11.0, -21.0, 21.0, 41.0),
+		it.Seq(bbox).Equal(-10.0, -20.0, +20.0, +40.0),
+		it.Seq(bbox3.Expand(1.0)).Equal(-11.0, -21.0, 0.0, 21.0, 41.0, 100.0),
+		it.Equiv(geojson.BoundingBox(nil).Expand(1.0), nil),
+		it.Equal(bbox.Width(), 30.0),
+		it.Equal(bbox.Height(), 60.0),
+		it.Equal(geojson.BoundingBox(nil).Width(), 0.0),
+		it.Equal(geojson.BoundingBox(nil).Height(), 0.0),
+	)
+}
+
+func TestFMapErr(t *testing.T) {
+	shapes := []geojson.Shape{
+		geojson.Coord{100.0, 0.0},
+		geojson.Curve{{101.0, 0.0}, {100.0, 0.0}},
+		geojson.Surface{{{101.0, 0.0}, {100.0, 0.0}, {102.0, 0.0}}},
+		geojson.Surfaces{{{{101.0, 0.0}}, {{100.0, 0.0}, {102.0, 0.0}}}},
+		geojson.Shapes{geojson.Coord{101.0, 0.0}, geojson.Curve{{100.0, 0.0}, {102.0, 0.0}}},
+	}
+
+	for _, shape := range shapes {
+		seq := []float64{}
+		err := shape.FMapErr(func(x geojson.Coord) error {
+			seq = append(seq, x.Lng())
+			if x.Lng() == 100.0 {
+				return geojson.ErrOutOfRange
+			}
+			return nil
+		})
+
+		it.Then(t).Should(
+			it.Equal(err, error(geojson.ErrOutOfRange)),
+			it.Equal(seq[len(seq)-1], 100.0),
+		)
+	}
+
+	err := geojson.Curve{{101.0, 0.0}}.FMapErr(func(geojson.Coord) error { return nil })
+	it.Then(t).Should(it.Nil(err))
+}
+
+func TestBBoxJoin(t *testing.T) {
+	box := geojson.BoundingBox{-10.0, -20.0, +10.0, +20.0}
+
+	t.Run("NilReceiver", func(t *testing.T) {
+		var bbox geojson.BoundingBox
+		bbox = bbox.Join(box)
+		bbox[0] = -100.0
+
+		it.Then(t).Should(
+			it.Equiv(bbox, geojson.BoundingBox{-100.0, -20.0, +10.0, +20.0}),
+			it.Equiv(box, geojson.BoundingBox{-10.0, -20.0, +10.0, +20.0}),
+		)
+	})
+
+	t.Run("NilArgument", func(t *testing.T) {
+		bbox := geojson.BoundingBox{-1.0, -2.0, +1.0, +2.0}
+		it.Then(t).Should(
+			it.Equiv(bbox.Join(nil), geojson.BoundingBox{-1.0, -2.0, +1.0, +2.0}),
+			it.Equiv(geojson.BoundingBox(nil).Join(nil), nil),
+		)
+	})
+
+	t.Run("Dimensions", func(t *testing.T) {
+		bbox2 := geojson.BoundingBox{-1.0, -2.0, +1.0, +2.0}
+		bbox3 := geojson.BoundingBox{-5.0, -5.0, -5.0, +5.0, +5.0, +5.0}
+		it.Then(t).Should(
+			it.Equiv(bbox2.Union(bbox3), geojson.BoundingBox{-5.0, -5.0, -5.0, +5.0, +5.0, +5.0}),
+			it.Equiv(bbox3.Union(bbox2), geojson.BoundingBox{-5.0, -5.0, -5.0, +5.0, +5.0, +5.0}),
+			it.Equiv(bbox2, geojson.BoundingBox{-1.0, -2.0, +1.0, +2.0}),
+		)
+	})
+}
+
+func TestBBoxMixedDimensions(t *testing.T) {
+	for expect, coords := range map[string]geojson.Curve{
+		"2D":        {{0.0, 0.0}, {1.0, 1.0}, {2.0, 2.0}},
+		"3D":        {{0.0, 0.0, 5.0}, {1.0, 1.0, 10.0}, {2.0, 2.0, 15.0}},
+		"3D middle": {{0.0, 0.0}, {1.0, 1.0, 10.0}, {2.0, 2.0}},
+		"3D first":  {{0.0, 0.0, 10.0}, {1.0, 1.0}, {2.0, 2.0, 5.0}},
+		"3D last":   {{0.0, 0.0}, {1.0, 1.0}, {2.0, 2.0, -5.0}},
+		"4D":        {{0.0, 0.0, 10.0, 1.0}, {1.0, 1.0}, {2.0, 2.0, 10.0, 100.0}},
+	} {
+		bbox := (&geojson.LineString{Coords: coords}).BoundingBox()
+		switch expect {
+		case "2D":
+			it.Then(t).Should(it.Equiv(bbox, geojson.BoundingBox{0.0, 0.0, 2.0, 2.0}))
+		case "3D":
+			it.Then(t).Should(it.Equiv(bbox, geojson.BoundingBox{0.0, 0.0, 5.0, 2.0, 2.0, 15.0}))
+		case "3D middle", "4D":
+			it.Then(t).Should(it.Equiv(bbox, geojson.BoundingBox{0.0, 0.0, 10.0, 2.0, 2.0, 10.0}))
+		case "3D first":
+			it.Then(t).Should(it.Equiv(bbox, geojson.BoundingBox{0.0, 0.0, 5.0, 2.0, 2.0, 10.0}))
+		case "3D last":
+			it.Then(t).Should(it.Equiv(bbox, geojson.BoundingBox{0.0, 0.0, -5.0, 2.0, 2.0, -5.0}))
+		}
+
+		it.Then(t).Should(
+			it.Seq(bbox.SouthWest()[:2]).Equal(0.0, 0.0),
+			it.Seq(bbox.NorthEast()[:2]).Equal(2.0, 2.0),
+		)
+	}
+}
+
+func TestBBoxMixedDimensionsCollection(t *testing.T) {
+	seq := []geojson.Feature{
+		geojson.NewPoint("a:2d", geojson.Coord{0.0, 0.0}),
+		geojson.NewPoint("a:3d", geojson.Coord{1.0, 1.0, 10.0}),
+		geojson.NewPoint("a:2d", geojson.Coord{2.0, 2.0}),
+	}
+
+	fwd := geojson.Collection[geojson.Feature]{Features: seq}
+	rev := geojson.Collection[geojson.Feature]{Features: []geojson.Feature{seq[2], seq[1], seq[0]}}
+	geo := &geojson.GeometryCollection{
+		Geometries: []geojson.Geometry{seq[0].Geometry, seq[1].Geometry, seq[2].Geometry},
+	}
+
+	it.Then(t).Should(
+		it.Equiv(fwd.BoundingBox(), geojson.BoundingBox{0.0, 0.0, 10.0, 2.0, 2.0, 10.0}),
+		it.Equiv(rev.BoundingBox(), geojson.BoundingBox{0.0, 0.0, 10.0, 2.0, 2.0, 10.0}),
+		it.Equiv(geo.BoundingBox(), geojson.BoundingBox{0.0, 0.0, 10.0, 2.0, 2.0, 10.0}),
+	)
+}
+
+func TestBBoxNotSeededFromOrigin(t *testing.T) {
+	origin := geojson.Coord{0.0, 0.0}
+	hel := geojson.Curve{{24.9, 60.1}, {25.0, 60.2}, {24.8, 60.3}, {24.9, 60.1}}
+	sto := geojson.Curve{{18.0, 59.3, 10.0}, {18.1, 59.4, 20.0}, {17.9, 59.5, 30.0}, {18.0, 59.3, 10.0}}
+
+	geos := []geojson.Geometry{
+		&geojson.Point{Coords: hel[0]},
+		&geojson.MultiPoint{Coords: hel},
+		&geojson.LineString{Coords: hel},
+		&geojson.MultiLineString{Coords: geojson.Surface{{}, hel, sto}},
+		&geojson.Polygon{Coords: geojson.Surface{hel}},
+		&geojson.MultiPolygon{Coords: geojson.Surfaces{{}, {hel}, {sto}}},
+		&geojson.GeometryCollection{Geometries: []geojson.Geometry{nil, &geojson.Point{}, &geojson.LineString{Coords: sto}}},
+	}
+
+	for _, geo := range geos {
+		bbox := geo.BoundingBox()
+		it.Then(t).Should(
+			it.True(len(bbox) >= 4),
+			it.True(!bbox.Contains(origin)),
+		)
+	}
+
+	it.Then(t).Should(
+		it.Equiv(geos[3].BoundingBox(), geojson.BoundingBox{17.9, 59.3, 10.0, 25.0, 60.3, 30.0}),
+		it.Equiv(geos[5].BoundingBox(), geojson.BoundingBox{17.9, 59.3, 10.0, 25.0, 60.3, 30.0}),
+	)
+
+	c := geojson.Collection[geojson.Feature]{
+		Features: []geojson.Feature{
+			{ID: "a:unlocated"},
+			geojson.NewLineString("a:hel", hel),
+			geojson.NewPolygon("a:sto", geojson.Surface{sto}),
+		},
+	}
+	it.Then(t).Should(
+		it.Equiv(c.BoundingBox(), geojson.BoundingBox{17.9, 59.3, 10.0, 25.0, 60.3, 30.0}),
+		it.True(!c.BoundingBox().Contains(origin)),
+		it.True(!geojson.UnionBoundingBox(geos...).Contains(origin)),
+	)
+}
+
+func TestBBoxUnion(t *testing.T) {
+	a := geojson.BoundingBox{-10.0, -20.0, +10.0, +20.0}
+	b := geojson.BoundingBox{0.0, 0.0, +30.0, +40.0}
+
+	it.Then(t).Should(
+		it.Equiv(a.Union(b), geojson.BoundingBox{-10.0, -20.0, +30.0, +40.0}),
+		it.Equiv(a, geojson.BoundingBox{-10.0, -20.0, +10.0, +20.0}),
+		it.Equiv(b, geojson.BoundingBox{0.0, 0.0, +30.0, +40.0}),
+		it.Equiv(geojson.BoundingBox(nil).Union(b), b),
+		it.Equiv(a.Union(nil), a),
+		it.Equiv(geojson.BoundingBox(nil).Union(nil), nil),
+	)
+}
+
+func TestUnionBoundingBox(t *testing.T) {
+	a := &geojson.Point{Coords: geojson.Coord{10.0, 20.0}}
+	b := &geojson.LineString{Coords: geojson.Curve{{30.0, 40.0}, {35.0, 45.0}}}
+
+	it.Then(t).Should(
+		it.Equiv(geojson.UnionBoundingBox(a, b), geojson.BoundingBox{10.0, 20.0, 35.0, 45.0}),
+		it.Equiv(geojson.UnionBoundingBox(nil, &geojson.Point{}, a), geojson.BoundingBox{10.0, 20.0, 10.0, 20.0}),
+		it.Equiv(geojson.UnionBoundingBox(a, &geojson.MultiPoint{}, nil, b), geojson.BoundingBox{10.0, 20.0, 35.0, 45.0}),
+		it.Equiv(geojson.UnionBoundingBox(nil, &geojson.Polygon{}), nil),
+		it.Equiv(geojson.UnionBoundingBox(), nil),
+		it.Equiv(a.BoundingBox(), geojson.BoundingBox{10.0, 20.0, 10.0, 20.0}),
+	)
+}
+
+func TestBBoxEqual(t *testing.T) {
+	a := geojson.BoundingBox{-10.0, -20.0, +10.0, +20.0}
+
+	it.Then(t).Should(
+		it.True(a.Equal(geojson.BoundingBox{-10.0, -20.0, +10.0, +20.0})),
+		it.True(!a.Equal(geojson.BoundingBox{-10.0, -20.0, +10.0, +20.000001})),
+		it.True(!a.Equal(geojson.BoundingBox{-10.0, -20.0, 0.0, +10.0, +20.0, 0.0})),
+		it.True(!a.Equal(nil)),
+		it.True(geojson.BoundingBox(nil).Equal(nil)),
+		it.True(geojson.BoundingBox(nil).Equal(geojson.BoundingBox{})),
+		it.True(!geojson.BoundingBox{math.NaN(), 0, 1, 1}.Equal(geojson.BoundingBox{math.NaN(), 0, 1, 1})),
+
+		it.True(a.EqualWithin(geojson.BoundingBox{-10.0, -20.0, +10.0, +20.000001}, 1e-3)),
+		it.True(!a.EqualWithin(geojson.BoundingBox{-10.0, -20.0, +10.0, +20.1}, 1e-3)),
+		it.True(!a.EqualWithin(geojson.BoundingBox{-10.0, -20.0, 0.0, +10.0, +20.0, 0.0}, 1.0)),
+		it.True(geojson.BoundingBox{math.Inf(-1), 0, 1, 1}.EqualWithin(geojson.BoundingBox{math.Inf(-1), 0, 1, 1}, 1e-3)),
+	)
+}
+
+func TestBBoxPolygon(t *testing.T) {
+	bbox := geojson.BoundingBox{-10.0, -20.0, 0.0, +10.0, +20.0, 100.0}
+	geo := bbox.Polygon()
+
+	it.Then(t).Should(
+		it.Equiv(geo.Coords, geojson.Surface{
+			{{-10.0, -20.0}, {+10.0, -20.0}, {+10.0, +20.0}, {-10.0, +20.0}, {-10.0, -20.0}},
+		}),
+		it.Equal(geo.IsClockwise(0), false),
+		it.Equiv(geo.BoundingBox(), geojson.BoundingBox{-10.0, -20.0, +10.0, +20.0}),
+		it.Equiv(geojson.BoundingBox(nil).Polygon(), nil),
+	)
+
+	fea := bbox.Feature("geo:box")
+	it.Then(t).Should(
+		it.Equal(fea.ID, "geo:box"),
+		it.Equiv(fea.Geometry, geojson.Geometry(geo)),
+		it.Equiv(geojson.BoundingBox{}.Feature("geo:box").Geometry, nil),
+	)
+}
+
+func TestCoordM(t *testing.T) {
+	c := geojson.Coord{100.0, 0.5, 10.0, 42.0}
+	m, has := c.M()
+	_, hasNot := geojson.Coord{100.0, 0.5, 10.0}.M()
+
+	it.Then(t).Should(
+		it.Equal(m, 42.0),
+		it.Equal(has, true),
+		it.Equal(hasNot, false),
+		it.Equal(c.Alt(), 10.0),
+	)
+}
@@ -0,0 +1,67 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import "math"
+
+// Projection converts positions between WGS84 and the projected coordinate
+// system. Forward projects lng, lat into x, y of the system, Inverse
+// converts x, y back. Elements beyond lng, lat (elevation, measure)
+// are retained as-is.
+type Projection interface {
+	Forward(Coord) Coord
+	Inverse(Coord) Coord
+}
+
+// Reproject returns new geometry with each position converted through
+// the Forward of projection.
+//
+//	tile := geojson.Reproject(geo, geojson.WebMercator{})
+func Reproject(geo Geometry, p Projection) Geometry {
+	return transform(geo, p.Forward)
+}
+
+// WebMercatorMaxLat is the maximum latitude of Web Mercator, the projected
+// world is a square.
+const WebMercatorMaxLat = 85.05112877980659
+
+// radius of spherical Web Mercator, it is semi-major axis of WGS84
+const webMercatorRadius = 6378137.0
+
+// WebMercator is the spherical Mercator projection (EPSG:3857) used by
+// web maps, it projects to meters. The projection is defined for latitude
+// within ±85.0511° (WebMercatorMaxLat); latitude outside of the range is
+// clamped to it by Forward.
+type WebMercator struct{}
+
+// Forward projects lng, lat to x, y of Web Mercator
+func (WebMercator) Forward(c Coord) Coord {
+	if len(c) < 2 {
+		return clone(c)
+	}
+
+	lat := math.Max(-WebMercatorMaxLat, math.Min(WebMercatorMaxLat, c.Lat()))
+
+	return append(Coord{
+		webMercatorRadius * radians(c.Lng()),
+		webMercatorRadius * math.Log(math.Tan(math.Pi/4+radians(lat)/2)),
+	}, c[2:]...)
+}
+
+// Inverse converts x, y of Web Mercator to lng, lat
+func (WebMercator) Inverse(c Coord) Coord {
+	if len(c) < 2 {
+		return clone(c)
+	}
+
+	return append(Coord{
+		degrees(c[0] / webMercatorRadius),
+		degrees(2*math.Atan(math.Exp(c[1]/webMercatorRadius)) - math.Pi/2),
+	}, c[2:]...)
+}
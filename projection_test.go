@@ -0,0 +1,57 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestWebMercator(t *testing.T) {
+	p := geojson.WebMercator{}
+	corner := p.Forward(geojson.Coord{180.0, geojson.WebMercatorMaxLat})
+	origin := p.Forward(geojson.Coord{0.0, 0.0, 10.0})
+	hel := p.Inverse(p.Forward(geojson.Coord{24.9384, 60.1699}))
+
+	it.Then(t).Should(
+		it.True(near(corner[0], 20037508.342789244, 1e-6)),
+		it.True(near(corner[1], 20037508.342789244, 1e-6)),
+		it.True(near(origin[0], 0.0, 1e-9)),
+		it.True(near(origin[1], 0.0, 1e-9)),
+		it.Equal(origin[2], 10.0),
+		it.True(nearCoord(hel, geojson.Coord{24.9384, 60.1699})),
+	)
+}
+
+func TestWebMercatorClamp(t *testing.T) {
+	p := geojson.WebMercator{}
+	north := p.Forward(geojson.Coord{0.0, 90.0})
+	south := p.Forward(geojson.Coord{0.0, -89.0})
+
+	it.Then(t).Should(
+		it.True(near(north[1], 20037508.342789244, 1e-6)),
+		it.True(near(south[1], -20037508.342789244, 1e-6)),
+		it.True(near(p.Inverse(north).Lat(), geojson.WebMercatorMaxLat, 1e-9)),
+	)
+}
+
+func TestReproject(t *testing.T) {
+	geo := &geojson.LineString{Coords: geojson.Curve{{0.0, 0.0}, {180.0, geojson.WebMercatorMaxLat}}}
+	seq := geojson.Reproject(geo, geojson.WebMercator{}).(*geojson.LineString)
+
+	it.Then(t).Should(
+		it.Equal(len(seq.Coords), 2),
+		it.True(near(seq.Coords[1][0], 20037508.342789244, 1e-6)),
+		it.True(near(seq.Coords[1][1], 20037508.342789244, 1e-6)),
+		it.Equiv(geo.Coords[1], geojson.Coord{180.0, geojson.WebMercatorMaxLat}),
+		it.Equiv(geojson.Reproject(nil, geojson.WebMercator{}), nil),
+	)
+}
@@ -0,0 +1,215 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// S2 cell hierarchy has 30 levels below the cube faces
+const s2MaxLevel = 30
+
+// S2Covering returns tokens of S2 cells covering the bounding box of
+// geometry. It uses the finest level up to maxLevel that covers the
+// box with at most maxCells cells, cells of the same level are returned
+// in the order of S2 cell ids. The covering is approximate, the box is
+// sampled at density of the half of cell's edge. Nil is returned for
+// geometry without positions.
+//
+// The S2 math is self-contained, the cell ids are compatible with S2
+// Geometry library (https://s2geometry.io).
+func S2Covering(geo Geometry, maxCells, maxLevel int) []string {
+	if geo == nil {
+		return nil
+	}
+
+	bbox := geo.BoundingBox()
+	if len(bbox) < 4 {
+		return nil
+	}
+
+	maxCells = max(1, maxCells)
+	level := max(0, min(s2MaxLevel, maxLevel, s2LevelFor(bbox, maxCells)))
+
+	for {
+		cells := s2CoverBBox(bbox, level)
+		if len(cells) <= maxCells || level == 0 {
+			seq := make([]string, len(cells))
+			for i, cell := range cells {
+				seq[i] = s2Token(cell)
+			}
+			return seq
+		}
+		level--
+	}
+}
+
+// finest level which average cell area fits maxCells into the box
+func s2LevelFor(bbox BoundingBox, maxCells int) int {
+	sw, ne := bbox.SouthWest(), bbox.NorthEast()
+	width := ne.Lng() - sw.Lng()
+	if width < 0 {
+		width += 360
+	}
+
+	// area of the box in steradians
+	area := radians(width) * math.Abs(math.Sin(radians(ne.Lat()))-math.Sin(radians(sw.Lat())))
+	if area <= 0 {
+		return s2MaxLevel
+	}
+
+	// average cell area at level is 4π / (6 · 4^level)
+	level := math.Log(float64(maxCells)*4*math.Pi/(6*area)) / math.Log(4)
+	return int(math.Floor(level))
+}
+
+// cells of the level covering the bounding box, sorted by cell id
+func s2CoverBBox(bbox BoundingBox, level int) []uint64 {
+	sw, ne := bbox.SouthWest(), bbox.NorthEast()
+	west, east := sw.Lng(), ne.Lng()
+	if east < west {
+		east += 360
+	}
+	south, north := sw.Lat(), ne.Lat()
+
+	// minimal edge of cell at the level is 2√2/3 / 2^level radians
+	step := degrees(2*math.Sqrt2/3/math.Ldexp(1, level)) / 2
+
+	cells := map[uint64]struct{}{}
+	for _, lat := range s2Steps(south, north, step) {
+		// Note: longitude step grows towards poles, the worst latitude
+		//       of the row is used to keep density of samples.
+		cos := math.Cos(radians(math.Min(90, math.Abs(lat)+step)))
+		lngStep := 360.0
+		if cos > 1e-9 {
+			lngStep = step / cos
+		}
+
+		for _, lng := range s2Steps(west, east, lngStep) {
+			cells[s2CellID(lat, lng, level)] = struct{}{}
+		}
+	}
+
+	seq := make([]uint64, 0, len(cells))
+	for cell := range cells {
+		seq = append(seq, cell)
+	}
+	slices.Sort(seq)
+	return seq
+}
+
+// samples of the range [lo, hi] at the step, both ends are included
+func s2Steps(lo, hi, step float64) []float64 {
+	n := int(math.Ceil((hi - lo) / step))
+	seq := make([]float64, 0, n+1)
+	for i := 0; i < n; i++ {
+		seq = append(seq, lo+float64(i)*step)
+	}
+	return append(seq, hi)
+}
+
+// Hilbert curve tables of S2 cell hierarchy, the index ij is (i << 1) | j
+var (
+	s2IJToPos = [4][4]uint64{
+		{0, 1, 3, 2}, // canonical order
+		{0, 3, 1, 2}, // axes swapped
+		{2, 3, 1, 0}, // bits inverted
+		{2, 1, 3, 0}, // swapped & inverted
+	}
+	s2PosToOrientation = [4]int{1, 0, 0, 3}
+)
+
+// id of the S2 cell at the level containing the position
+func s2CellID(lat, lng float64, level int) uint64 {
+	φ, λ := radians(lat), radians(lng)
+	x, y, z := math.Cos(φ)*math.Cos(λ), math.Cos(φ)*math.Sin(λ), math.Sin(φ)
+
+	face, u, v := s2Face(x, y, z)
+	i, j := s2IJ(u), s2IJ(v)
+
+	// Note: orientation of the Hilbert curve at the face is its swap bit
+	orientation := face & 1
+	pos := uint64(0)
+	for k := s2MaxLevel - 1; k >= 0; k-- {
+		ij := ((i>>k)&1)<<1 | ((j >> k) & 1)
+		digit := s2IJToPos[orientation][ij]
+		pos = pos<<2 | digit
+		orientation ^= s2PosToOrientation[digit]
+	}
+
+	id := uint64(face)<<61 | pos<<1 | 1
+	lsb := uint64(1) << (2 * (s2MaxLevel - level))
+	return id&-lsb | lsb
+}
+
+// cube face of the unit vector and its face coordinates (u, v)
+func s2Face(x, y, z float64) (int, float64, float64) {
+	ax, ay, az := math.Abs(x), math.Abs(y), math.Abs(z)
+
+	face := 0
+	switch {
+	case ax >= ay && ax >= az:
+		face = 0
+		if x < 0 {
+			face = 3
+		}
+	case ay >= az:
+		face = 1
+		if y < 0 {
+			face = 4
+		}
+	default:
+		face = 2
+		if z < 0 {
+			face = 5
+		}
+	}
+
+	switch face {
+	case 0:
+		return face, y / x, z / x
+	case 1:
+		return face, -x / y, z / y
+	case 2:
+		return face, -x / z, -y / z
+	case 3:
+		return face, z / x, y / x
+	case 4:
+		return face, z / y, -x / y
+	default:
+		return face, -y / z, -x / z
+	}
+}
+
+// leaf cell coordinate of face coordinate, it uses quadratic projection
+func s2IJ(u float64) int {
+	var s float64
+	if u >= 0 {
+		s = 0.5 * math.Sqrt(1+3*u)
+	} else {
+		s = 1 - 0.5*math.Sqrt(1-3*u)
+	}
+
+	n := 1 << s2MaxLevel
+	return min(n-1, max(0, int(math.Floor(s*float64(n)))))
+}
+
+// token is hex encoding of the cell id without trailing zeros
+func s2Token(id uint64) string {
+	if id == 0 {
+		return "X"
+	}
+
+	s := strconv.FormatUint(id, 16)
+	s = strings.Repeat("0", 16-len(s)) + s
+	return strings.TrimRight(s, "0")
+}
@@ -0,0 +1,70 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestS2CoveringPoint(t *testing.T) {
+	nyc := &geojson.Point{Coords: geojson.Coord{-74.0060, 40.7128}}
+	sfo := &geojson.Point{Coords: geojson.Coord{-122.4194, 37.7749}}
+
+	it.Then(t).Should(
+		it.Seq(geojson.S2Covering(nyc, 1, 12)).Equal("89c25a3"),
+		it.Seq(geojson.S2Covering(sfo, 1, 12)).Equal("8085809"),
+		it.Seq(geojson.S2Covering(nyc, 1, 0)).Equal("9"),
+	)
+
+	// cells of finer levels are nested into coarser ones
+	it.Then(t).Should(
+		it.True(strings.HasPrefix(geojson.S2Covering(nyc, 1, 20)[0], "89c25a")),
+		it.True(strings.HasPrefix(geojson.S2Covering(nyc, 1, 30)[0], "89c25a")),
+	)
+}
+
+func TestS2CoveringFaces(t *testing.T) {
+	world := &geojson.Polygon{
+		Coords: geojson.Surface{{{-180, -90}, {180, -90}, {180, 90}, {-180, 90}, {-180, -90}}},
+	}
+
+	it.Then(t).Should(
+		it.Seq(geojson.S2Covering(world, 8, 30)).Equal("1", "3", "5", "7", "9", "b"),
+		it.Seq(geojson.S2Covering(world, 1, 30)).Equal("1", "3", "5", "7", "9", "b"),
+	)
+}
+
+func TestS2CoveringPolygon(t *testing.T) {
+	geo := &geojson.Polygon{
+		Coords: geojson.Surface{{{24, 60}, {25, 60}, {25, 61}, {24, 61}, {24, 60}}},
+	}
+	hel := geojson.S2Covering(&geojson.Point{Coords: geojson.Coord{24.5, 60.5}}, 1, 8)[0]
+
+	for _, maxCells := range []int{1, 4, 8, 32} {
+		cells := geojson.S2Covering(geo, maxCells, 30)
+		it.Then(t).Should(
+			it.True(len(cells) > 0),
+			it.True(len(cells) <= maxCells),
+		)
+	}
+
+	found := false
+	for _, cell := range geojson.S2Covering(geo, 32, 8) {
+		found = found || cell == hel
+	}
+	it.Then(t).Should(
+		it.True(found),
+		it.Equal(len(geojson.S2Covering(nil, 8, 30)), 0),
+		it.Equal(len(geojson.S2Covering(&geojson.Polygon{}, 8, 30)), 0),
+	)
+}
@@ -0,0 +1,96 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// record separator of GeoJSON text sequence (RFC 8142)
+const recordSeparator = 0x1e
+
+// LineReader reads newline-delimited GeoJSON, one feature per line.
+// The record separator of GeoJSON text sequence (RFC 8142) is accepted
+// at the beginning of the line, blank lines are skipped.
+type LineReader[T any] struct {
+	r    *bufio.Reader
+	line int
+}
+
+// NewLineReader creates reader of newline-delimited GeoJSON
+func NewLineReader[T any](r io.Reader) *LineReader[T] {
+	return &LineReader[T]{r: bufio.NewReader(r)}
+}
+
+// Read next feature from the stream, it returns io.EOF at the end of stream.
+// Decode errors are annotated with the line number.
+func (lr *LineReader[T]) Read() (T, error) {
+	var val T
+
+	for {
+		b, err := lr.r.ReadBytes('\n')
+		if len(b) == 0 && err != nil {
+			return val, err
+		}
+		lr.line++
+
+		b = bytes.TrimSpace(bytes.TrimLeft(bytes.TrimSpace(b), "\x1e"))
+		if len(b) == 0 {
+			if err != nil {
+				return val, err
+			}
+			continue
+		}
+
+		if err := json.Unmarshal(b, &val); err != nil {
+			return val, fmt.Errorf("line %d: %w", lr.line, err)
+		}
+
+		return val, nil
+	}
+}
+
+// LineWriter writes newline-delimited GeoJSON, one compact feature per line.
+type LineWriter[T any] struct {
+	w  io.Writer
+	rs bool
+}
+
+// NewLineWriter creates writer of newline-delimited GeoJSON
+func NewLineWriter[T any](w io.Writer) *LineWriter[T] {
+	return &LineWriter[T]{w: w}
+}
+
+// NewTextSeqWriter creates writer of GeoJSON text sequence (RFC 8142),
+// each feature is prefixed with record separator.
+func NewTextSeqWriter[T any](w io.Writer) *LineWriter[T] {
+	return &LineWriter[T]{w: w, rs: true}
+}
+
+// Write feature to the stream
+func (lw *LineWriter[T]) Write(v T) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 0, len(b)+2)
+	if lw.rs {
+		buf = append(buf, recordSeparator)
+	}
+	buf = append(buf, b...)
+	buf = append(buf, '\n')
+
+	_, err = lw.w.Write(buf)
+	return err
+}
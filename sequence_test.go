@@ -0,0 +1,86 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+const featureLine = `{"type":"Feature","geometry":{"type":"Point","coordinates":[102.0,0.5]},"properties":{"name":"Helsinki"}}`
+
+func TestLineWriterReader(t *testing.T) {
+	for _, rs := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+
+		w := geojson.NewLineWriter[GeoJsonCity](buf)
+		if rs {
+			w = geojson.NewTextSeqWriter[GeoJsonCity](buf)
+		}
+
+		for _, city := range testCities().Features {
+			it.Then(t).Should(it.Nil(w.Write(city)))
+		}
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		it.Then(t).Should(
+			it.Equal(len(lines), 3),
+			it.Equal(strings.HasPrefix(lines[0], "\x1e"), rs),
+		)
+
+		r := geojson.NewLineReader[GeoJsonCity](buf)
+		for _, expect := range testCities().Features {
+			city, err := r.Read()
+			it.Then(t).Should(
+				it.Nil(err),
+				it.Equal(city.Name, expect.Name),
+				it.Equal(city.ID, expect.ID),
+			)
+		}
+
+		_, err := r.Read()
+		it.Then(t).Should(
+			it.Equal(err, io.EOF),
+		)
+	}
+}
+
+func TestLineReaderBlankLines(t *testing.T) {
+	r := geojson.NewLineReader[GeoJsonCity](strings.NewReader(
+		"\n" + featureLine + "\n\n  \n\x1e" + featureLine,
+	))
+
+	a, err := r.Read()
+	it.Then(t).Should(it.Nil(err), it.Equal(a.Name, "Helsinki"))
+
+	b, err := r.Read()
+	it.Then(t).Should(it.Nil(err), it.Equal(b.Name, "Helsinki"))
+
+	_, err = r.Read()
+	it.Then(t).Should(it.Equal(err, io.EOF))
+}
+
+func TestLineReaderError(t *testing.T) {
+	r := geojson.NewLineReader[GeoJsonCity](strings.NewReader(
+		featureLine + "\n\n{\"type\":\"Feature\",\n",
+	))
+
+	_, err := r.Read()
+	it.Then(t).Should(it.Nil(err))
+
+	_, err = r.Read()
+	it.Then(t).Should(
+		it.Fail(func() error { return err }).Contain("line 3:"),
+	)
+}
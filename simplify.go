@@ -0,0 +1,124 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import "math"
+
+// planar distance from position c to segment a, b in coordinate units
+func planarDistance(c, a, b Coord) float64 {
+	dx, dy := b.Lng()-a.Lng(), b.Lat()-a.Lat()
+	px, py := c.Lng()-a.Lng(), c.Lat()-a.Lat()
+
+	if d := dx*dx + dy*dy; d > 0 {
+		t := math.Max(0, math.Min(1, (px*dx+py*dy)/d))
+		px, py = px-t*dx, py-t*dy
+	}
+
+	return math.Hypot(px, py)
+}
+
+// simplify curve using Douglas-Peucker algorithm, end positions are retained.
+// At least one intermediate position is retained if keep is set.
+func (seq Curve) simplify(tolerance float64, keep bool) Curve {
+	if len(seq) <= 2 {
+		return transformCurve(seq, clone)
+	}
+
+	mark := make([]bool, len(seq))
+	mark[0], mark[len(seq)-1] = true, true
+
+	var split func(i, j int, force bool)
+	split = func(i, j int, force bool) {
+		if j-i < 2 {
+			return
+		}
+
+		k, d := i, -1.0
+		for x := i + 1; x < j; x++ {
+			if v := planarDistance(seq[x], seq[i], seq[j]); v > d {
+				k, d = x, v
+			}
+		}
+
+		if force || d > tolerance {
+			mark[k] = true
+			split(i, k, false)
+			split(k, j, false)
+		}
+	}
+	split(0, len(seq)-1, keep)
+
+	curve := Curve{}
+	for i, x := range seq {
+		if mark[i] {
+			curve = append(curve, clone(x))
+		}
+	}
+	return curve
+}
+
+// simplify closed ring, the ring is split at the position farthest from
+// the start so that simplified ring has at least four positions.
+func (seq Curve) simplifyRing(tolerance float64) Curve {
+	if len(seq) < 4 {
+		return transformCurve(seq, clone)
+	}
+
+	k, d := 0, -1.0
+	for i, x := range seq {
+		if v := planarDistance(x, seq[0], seq[0]); v > d {
+			k, d = i, v
+		}
+	}
+
+	head := seq[:k+1].simplify(tolerance, true)
+	tail := seq[k:].simplify(tolerance, true)
+	return append(head, tail[1:]...)
+}
+
+func (seq Surface) simplify(tolerance float64) Surface {
+	surface := make(Surface, len(seq))
+	for i, ring := range seq {
+		surface[i] = ring.simplifyRing(tolerance)
+	}
+	return surface
+}
+
+// Simplify returns new line string simplified using Douglas-Peucker
+// algorithm. The tolerance is defined in coordinate units (degrees).
+func (geo *LineString) Simplify(tolerance float64) *LineString {
+	return &LineString{Coords: geo.Coords.simplify(tolerance, false)}
+}
+
+// Simplify returns new multi line string, each line is simplified using
+// Douglas-Peucker algorithm. The tolerance is defined in coordinate units.
+func (geo *MultiLineString) Simplify(tolerance float64) *MultiLineString {
+	seq := make(Surface, len(geo.Coords))
+	for i, line := range geo.Coords {
+		seq[i] = line.simplify(tolerance, false)
+	}
+	return &MultiLineString{Coords: seq}
+}
+
+// Simplify returns new polygon simplified using Douglas-Peucker algorithm.
+// The tolerance is defined in coordinate units (degrees). Rings remain
+// closed and retain at least four positions.
+func (geo *Polygon) Simplify(tolerance float64) *Polygon {
+	return &Polygon{Coords: geo.Coords.simplify(tolerance)}
+}
+
+// Simplify returns new multi polygon, each polygon is simplified using
+// Douglas-Peucker algorithm, see Polygon.Simplify.
+func (geo *MultiPolygon) Simplify(tolerance float64) *MultiPolygon {
+	seq := make(Surfaces, len(geo.Coords))
+	for i, surface := range geo.Coords {
+		seq[i] = surface.simplify(tolerance)
+	}
+	return &MultiPolygon{Coords: seq}
+}
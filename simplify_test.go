@@ -0,0 +1,46 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestLineStringSimplify(t *testing.T) {
+	geo := &geojson.LineString{Coords: geojson.Curve{
+		{0.0, 0.0}, {1.0, 0.001}, {2.0, -0.001}, {3.0, 0.0, 10.0}, {4.0, 1.0}, {5.0, 1.001}, {6.0, 1.0},
+	}}
+
+	it.Then(t).Should(
+		it.Equiv(geo.Simplify(0.01).Coords, geojson.Curve{{0.0, 0.0}, {3.0, 0.0, 10.0}, {4.0, 1.0}, {6.0, 1.0}}),
+		it.Equiv(geo.Simplify(0.0).Coords, geo.Coords),
+		it.Equiv(geo.Simplify(10.0).Coords, geojson.Curve{{0.0, 0.0}, {6.0, 1.0}}),
+		it.Equal(len(geo.Coords), 7),
+	)
+}
+
+func TestPolygonSimplify(t *testing.T) {
+	geo := &geojson.Polygon{Coords: geojson.Surface{
+		{{0.0, 0.0}, {0.5, 0.001}, {1.0, 0.0}, {1.001, 0.5}, {1.0, 1.0}, {0.0, 1.0}, {0.0, 0.0}},
+	}}
+
+	it.Then(t).Should(
+		it.Equiv(geo.Simplify(0.01).Coords,
+			geojson.Surface{{{0.0, 0.0}, {1.0, 0.0}, {1.0, 1.0}, {0.0, 1.0}, {0.0, 0.0}}},
+		),
+		it.True(len(geo.Simplify(10.0).Coords[0]) >= 4),
+		it.Equiv(
+			(&geojson.MultiPolygon{Coords: geojson.Surfaces{geo.Coords}}).Simplify(0.01).Coords,
+			geojson.Surfaces{geo.Simplify(0.01).Coords},
+		),
+	)
+}
@@ -0,0 +1,121 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import "math"
+
+// Snap returns new geometry with longitude and latitude of positions rounded
+// to the nearest multiple of grid size (degrees), other elements of positions
+// are retained. Consecutive positions of curves and rings collapsed by
+// snapping are removed, rings remain closed. Parts of geometry degenerated
+// by snapping are dropped: lines of less than 2 positions, rings of less than
+// 4 positions and polygons without exterior ring. Snap returns nil if all
+// parts of geometry are degenerated. The geometry is copied as-is if grid
+// size is not positive.
+func Snap(geo Geometry, gridSize float64) Geometry {
+	if gridSize <= 0 {
+		return transform(geo, clone)
+	}
+
+	snap := func(c Coord) Coord {
+		x := clone(c)
+		for i := 0; i < len(x) && i < 2; i++ {
+			x[i] = math.Round(x[i]/gridSize) * gridSize
+		}
+		return x
+	}
+
+	return snapGeometry(geo, snap)
+}
+
+func snapGeometry(geo Geometry, snap func(Coord) Coord) Geometry {
+	switch v := geo.(type) {
+	case *Point:
+		return transform(v, snap)
+	case *MultiPoint:
+		return transform(v, snap)
+	case *LineString:
+		if seq := snapCurve(v.Coords, snap, 2); seq != nil {
+			return &LineString{Coords: seq}
+		}
+	case *MultiLineString:
+		seq := Surface{}
+		for _, x := range v.Coords {
+			if curve := snapCurve(x, snap, 2); curve != nil {
+				seq = append(seq, curve)
+			}
+		}
+		if len(seq) > 0 {
+			return &MultiLineString{Coords: seq}
+		}
+	case *Polygon:
+		if seq := snapSurface(v.Coords, snap); seq != nil {
+			return &Polygon{Coords: seq}
+		}
+	case *MultiPolygon:
+		seq := Surfaces{}
+		for _, x := range v.Coords {
+			if surface := snapSurface(x, snap); surface != nil {
+				seq = append(seq, surface)
+			}
+		}
+		if len(seq) > 0 {
+			return &MultiPolygon{Coords: seq}
+		}
+	case *GeometryCollection:
+		seq := []Geometry{}
+		for _, x := range v.Geometries {
+			if g := snapGeometry(x, snap); g != nil {
+				seq = append(seq, g)
+			}
+		}
+		if len(seq) > 0 {
+			return &GeometryCollection{Geometries: seq}
+		}
+	}
+
+	return nil
+}
+
+// snapped curve without consecutive duplicates, nil if less then n positions
+func snapCurve(seq Curve, snap func(Coord) Coord, n int) Curve {
+	curve := make(Curve, 0, len(seq))
+	for _, c := range seq {
+		x := snap(c)
+		if k := len(curve); k > 0 && curve[k-1].Lng() == x.Lng() && curve[k-1].Lat() == x.Lat() {
+			continue
+		}
+		curve = append(curve, x)
+	}
+
+	if len(curve) < n {
+		return nil
+	}
+	return curve
+}
+
+// snapped rings of polygon, degenerated holes are dropped
+func snapSurface(seq Surface, snap func(Coord) Coord) Surface {
+	if len(seq) == 0 {
+		return nil
+	}
+
+	exterior := snapCurve(seq[0], snap, 4)
+	if exterior == nil {
+		return nil
+	}
+
+	surface := Surface{exterior}
+	for _, hole := range seq[1:] {
+		if ring := snapCurve(hole, snap, 4); ring != nil {
+			surface = append(surface, ring)
+		}
+	}
+	return surface
+}
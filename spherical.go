@@ -0,0 +1,342 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import "math"
+
+// EarthRadius is the mean radius of WGS84 ellipsoid in meters
+const EarthRadius = 6371008.8
+
+func radians(deg float64) float64 { return deg * math.Pi / 180.0 }
+func degrees(rad float64) float64 { return rad * 180.0 / math.Pi }
+
+// Distance between two positions in meters, it uses haversine formula
+// over the sphere of EarthRadius. The elevation is ignored.
+func Distance(a, b Coord) float64 {
+	if len(a) < 2 || len(b) < 2 {
+		return 0
+	}
+
+	lat1, lng1 := a.LatLng()
+	lat2, lng2 := b.LatLng()
+
+	φ1, φ2 := radians(lat1), radians(lat2)
+	Δφ := radians(lat2 - lat1)
+	Δλ := radians(lng2 - lng1)
+
+	h := math.Sin(Δφ/2)*math.Sin(Δφ/2) +
+		math.Cos(φ1)*math.Cos(φ2)*math.Sin(Δλ/2)*math.Sin(Δλ/2)
+
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// length of the curve in meters
+func (seq Curve) length() float64 {
+	d := 0.0
+	for i := 1; i < len(seq); i++ {
+		d += Distance(seq[i-1], seq[i])
+	}
+	return d
+}
+
+// Length of LineString in meters
+func (geo *LineString) Length() float64 { return geo.Coords.length() }
+
+// length of all curves in meters
+func (seq Surface) length() float64 {
+	d := 0.0
+	for _, curve := range seq {
+		d += curve.length()
+	}
+	return d
+}
+
+// Length of geometry in meters. It is the polyline length of (multi)line
+// strings, the perimeter of (multi)polygons including holes and the sum of
+// lengths of GeometryCollection members. Points have zero length.
+func Length(geo Geometry) float64 {
+	switch g := geo.(type) {
+	case *LineString:
+		return g.Coords.length()
+	case *MultiLineString:
+		return g.Coords.length()
+	case *Polygon:
+		return g.Coords.length()
+	case *MultiPolygon:
+		d := 0.0
+		for _, surface := range g.Coords {
+			d += surface.length()
+		}
+		return d
+	case *GeometryCollection:
+		d := 0.0
+		for _, x := range g.Geometries {
+			d += Length(x)
+		}
+		return d
+	default:
+		return 0
+	}
+}
+
+// signed area of the ring in square meters, positive sign for counter
+// clockwise ring as of planarArea. It uses spherical excess approximation (Chamberlain and
+// Duquette, "Some Algorithms for Polygons on a Sphere"). Degenerated
+// rings of less than four positions have zero area.
+func (seq Curve) signedArea() float64 {
+	n := len(seq)
+	if n < 4 {
+		return 0
+	}
+
+	area := 0.0
+	for i := 0; i < n-1; i++ {
+		lo, md, hi := seq[(i+n-2)%(n-1)], seq[i], seq[(i+1)%(n-1)]
+		area += (radians(lo.Lng()) - radians(hi.Lng())) * math.Sin(radians(md.Lat()))
+	}
+
+	return area * EarthRadius * EarthRadius / 2
+}
+
+// area of polygon rings, interior rings are holes
+func (seq Surface) area() float64 {
+	if len(seq) == 0 {
+		return 0
+	}
+
+	area := math.Abs(seq[0].signedArea())
+	for _, hole := range seq[1:] {
+		area -= math.Abs(hole.signedArea())
+	}
+
+	return math.Max(0, area)
+}
+
+// Area of Polygon in square meters, holes are subtracted regardless
+// of their winding order.
+func (geo *Polygon) Area() float64 { return geo.Coords.area() }
+
+// Area of MultiPolygon in square meters, sum of polygons areas.
+func (geo *MultiPolygon) Area() float64 {
+	area := 0.0
+	for _, surface := range geo.Coords {
+		area += surface.area()
+	}
+	return area
+}
+
+// Bearing is the initial bearing (forward azimuth) of the great circle
+// path from one position to another, in degrees clockwise from north
+// within the range [0, 360).
+func Bearing(from, to Coord) float64 {
+	if len(from) < 2 || len(to) < 2 {
+		return 0
+	}
+
+	φ1, φ2 := radians(from.Lat()), radians(to.Lat())
+	Δλ := radians(to.Lng() - from.Lng())
+
+	y := math.Sin(Δλ) * math.Cos(φ2)
+	x := math.Cos(φ1)*math.Sin(φ2) - math.Sin(φ1)*math.Cos(φ2)*math.Cos(Δλ)
+
+	return math.Mod(degrees(math.Atan2(y, x))+360.0, 360.0)
+}
+
+// Destination position given distance in meters and bearing in degrees
+// clockwise from north from the start position, along great circle path.
+// The elevation of start position is preserved.
+func Destination(from Coord, distanceMeters, bearingDeg float64) Coord {
+	if len(from) < 2 {
+		return Coord{}
+	}
+
+	δ := distanceMeters / EarthRadius
+	θ := radians(bearingDeg)
+	φ1, λ1 := radians(from.Lat()), radians(from.Lng())
+
+	φ2 := math.Asin(math.Sin(φ1)*math.Cos(δ) + math.Cos(φ1)*math.Sin(δ)*math.Cos(θ))
+	λ2 := λ1 + math.Atan2(
+		math.Sin(θ)*math.Sin(δ)*math.Cos(φ1),
+		math.Cos(δ)-math.Sin(φ1)*math.Sin(φ2),
+	)
+
+	// normalise longitude to [-180, 180)
+	lng := math.Mod(degrees(λ2)+540.0, 360.0) - 180.0
+
+	c := make(Coord, len(from))
+	copy(c, from)
+	c[0], c[1] = lng, degrees(φ2)
+	return c
+}
+
+// BoundingBoxAround returns bounding box enclosing the circle of radiusMeters
+// around the center. The box spans all longitudes if the circle covers
+// the pole. The box crossing the antimeridian has west edge greater than
+// east one, as defined by RFC 7946.
+func BoundingBoxAround(center Coord, radiusMeters float64) BoundingBox {
+	if len(center) < 2 {
+		return nil
+	}
+
+	δ := radiusMeters / EarthRadius
+	φ, λ := radians(center.Lat()), radians(center.Lng())
+	south, north := φ-δ, φ+δ
+
+	if south <= -math.Pi/2 || north >= math.Pi/2 {
+		return BoundingBox{
+			-180.0, degrees(math.Max(south, -math.Pi/2)),
+			+180.0, degrees(math.Min(north, math.Pi/2)),
+		}
+	}
+
+	Δλ := math.Asin(math.Sin(δ) / math.Cos(φ))
+	west, east := degrees(λ-Δλ), degrees(λ+Δλ)
+	if west < -180.0 {
+		west += 360.0
+	}
+	if east > 180.0 {
+		east -= 360.0
+	}
+
+	return BoundingBox{west, degrees(south), east, degrees(north)}
+}
+
+// nearestOnSegment projects position c on the segment a, b. The projection
+// uses equirectangular approximation around c and clamps to segment endpoints.
+func nearestOnSegment(c, a, b Coord) Coord {
+	k := math.Cos(radians(c.Lat()))
+	ax, ay := (a.Lng()-c.Lng())*k, a.Lat()-c.Lat()
+	bx, by := (b.Lng()-c.Lng())*k, b.Lat()-c.Lat()
+
+	dx, dy := bx-ax, by-ay
+	l := dx*dx + dy*dy
+	if l == 0 {
+		return clone(a)
+	}
+
+	t := math.Max(0, math.Min(1, -(ax*dx+ay*dy)/l))
+	return interpolate(a, b, t)
+}
+
+// nearest position on the curve and distance to it in meters
+func (seq Curve) nearest(c Coord) (Coord, float64) {
+	switch len(seq) {
+	case 0:
+		return Coord{}, math.Inf(1)
+	case 1:
+		return clone(seq[0]), Distance(c, seq[0])
+	}
+
+	var p Coord
+	d := math.Inf(1)
+	for i := 1; i < len(seq); i++ {
+		x := nearestOnSegment(c, seq[i-1], seq[i])
+		if dx := Distance(c, x); dx < d {
+			p, d = x, dx
+		}
+	}
+
+	return p, d
+}
+
+// NearestPoint on the line string to the given position, and distance
+// to it in meters. The point always lays on the line string. It returns
+// empty Coord and +Inf distance for the empty line string.
+func (geo *LineString) NearestPoint(c Coord) (Coord, float64) {
+	return geo.Coords.nearest(c)
+}
+
+// SignedDistance from the position to the nearest edge of polygon rings in
+// meters. The distance is negative if polygon contains the position and
+// positive otherwise, the position within the hole is outside of polygon.
+// It is zero on the boundary and +Inf for polygon without rings.
+//
+// Note: the containment is tested at plane of lng, lat coordinates, see
+// Contains for details.
+func (geo *Polygon) SignedDistance(c Coord) float64 {
+	d := math.Inf(1)
+	for _, ring := range geo.Coords {
+		if _, dx := ring.nearest(c); dx < d {
+			d = dx
+		}
+	}
+
+	if d == 0 || math.IsInf(d, 1) {
+		return d
+	}
+
+	if geo.Coords.contains(c) {
+		return -d
+	}
+	return d
+}
+
+// intermediate position at fraction f along great circle path from a to b.
+// Elevation is linearly interpolated if both positions define it.
+func intermediate(a, b Coord, f float64) Coord {
+	δ := Distance(a, b) / EarthRadius
+	if δ == 0 {
+		return clone(a)
+	}
+
+	φ1, λ1 := radians(a.Lat()), radians(a.Lng())
+	φ2, λ2 := radians(b.Lat()), radians(b.Lng())
+
+	ka := math.Sin((1-f)*δ) / math.Sin(δ)
+	kb := math.Sin(f*δ) / math.Sin(δ)
+
+	x := ka*math.Cos(φ1)*math.Cos(λ1) + kb*math.Cos(φ2)*math.Cos(λ2)
+	y := ka*math.Cos(φ1)*math.Sin(λ1) + kb*math.Cos(φ2)*math.Sin(λ2)
+	z := ka*math.Sin(φ1) + kb*math.Sin(φ2)
+
+	c := Coord{degrees(math.Atan2(y, x)), degrees(math.Atan2(z, math.Hypot(x, y)))}
+	if a.HasAlt() && b.HasAlt() {
+		c = append(c, a.Alt()+f*(b.Alt()-a.Alt()))
+	}
+	return c
+}
+
+// densify the curve, so that no segment exceeds the given length
+func (seq Curve) densify(maxSegmentMeters float64) Curve {
+	if len(seq) == 0 {
+		return Curve{}
+	}
+
+	curve := Curve{clone(seq[0])}
+	for i := 1; i < len(seq); i++ {
+		a, b := seq[i-1], seq[i]
+
+		if maxSegmentMeters > 0 {
+			n := math.Ceil(Distance(a, b) / maxSegmentMeters)
+			for k := 1.0; k < n; k++ {
+				curve = append(curve, intermediate(a, b, k/n))
+			}
+		}
+
+		curve = append(curve, clone(b))
+	}
+	return curve
+}
+
+// Densify returns new line string with intermediate positions inserted along
+// great circle path, so that no segment exceeds maxSegmentMeters.
+func (geo *LineString) Densify(maxSegmentMeters float64) *LineString {
+	return &LineString{Coords: geo.Coords.densify(maxSegmentMeters)}
+}
+
+// Densify returns new polygon with intermediate positions inserted along
+// great circle path, so that no segment of rings exceeds maxSegmentMeters.
+// Rings remain closed.
+func (geo *Polygon) Densify(maxSegmentMeters float64) *Polygon {
+	seq := make(Surface, len(geo.Coords))
+	for i, ring := range geo.Coords {
+		seq[i] = ring.densify(maxSegmentMeters)
+	}
+	return &Polygon{Coords: seq}
+}
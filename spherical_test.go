@@ -0,0 +1,241 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+var (
+	coordHelsinki  = geojson.Coord{24.9384, 60.1699}
+	coordStockholm = geojson.Coord{18.0686, 59.3293}
+)
+
+func near(a, b, eps float64) bool { return math.Abs(a-b) <= eps }
+
+func TestDistance(t *testing.T) {
+	d := geojson.Distance(coordHelsinki, coordStockholm)
+
+	it.Then(t).Should(
+		it.True(near(d, 396_000, 2_000)),
+		it.Equal(geojson.Distance(coordHelsinki, coordHelsinki), 0.0),
+		it.Equal(geojson.Distance(coordHelsinki, geojson.Coord{}), 0.0),
+		it.Equal(
+			geojson.Distance(geojson.Coord{24.9384, 60.1699, 100.0}, coordStockholm),
+			d,
+		),
+	)
+}
+
+func TestLineStringLength(t *testing.T) {
+	geo := geojson.LineString{
+		Coords: geojson.Curve{coordHelsinki, coordStockholm, coordHelsinki},
+	}
+	d := geojson.Distance(coordHelsinki, coordStockholm)
+
+	it.Then(t).Should(
+		it.True(near(geo.Length(), 2*d, 1e-6)),
+		it.Equal((&geojson.LineString{}).Length(), 0.0),
+		it.Equal((&geojson.LineString{Coords: geojson.Curve{coordHelsinki}}).Length(), 0.0),
+	)
+}
+
+func TestLength(t *testing.T) {
+	d := geojson.Distance(coordHelsinki, coordStockholm)
+	line := geojson.Curve{coordHelsinki, coordStockholm}
+	ring := geojson.Curve{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}
+	hole := geojson.Curve{{0.2, 0.2}, {0.2, 0.8}, {0.8, 0.8}, {0.8, 0.2}, {0.2, 0.2}}
+	perimeter := geojson.Length(&geojson.LineString{Coords: ring})
+	inner := geojson.Length(&geojson.LineString{Coords: hole})
+
+	it.Then(t).Should(
+		it.Equal(geojson.Length(&geojson.Point{Coords: coordHelsinki}), 0.0),
+		it.Equal(geojson.Length(&geojson.MultiPoint{Coords: line}), 0.0),
+		it.True(near(geojson.Length(&geojson.LineString{Coords: line}), d, 1e-6)),
+		it.True(near(geojson.Length(&geojson.MultiLineString{Coords: geojson.Surface{line, line}}), 2*d, 1e-6)),
+		it.True(near(geojson.Length(&geojson.Polygon{Coords: geojson.Surface{ring, hole}}), perimeter+inner, 1e-6)),
+		it.True(near(geojson.Length(&geojson.MultiPolygon{Coords: []geojson.Surface{{ring}, {ring, hole}}}), 2*perimeter+inner, 1e-6)),
+		it.True(near(geojson.Length(&geojson.GeometryCollection{
+			Geometries: []geojson.Geometry{
+				&geojson.Point{Coords: coordHelsinki},
+				&geojson.LineString{Coords: line},
+				&geojson.Polygon{Coords: geojson.Surface{ring}},
+			},
+		}), d+perimeter, 1e-6)),
+		it.Equal(geojson.Length(nil), 0.0),
+	)
+}
+
+func TestPolygonArea(t *testing.T) {
+	// 1x1 degree square at equator is about 12364 km²
+	square := geojson.Curve{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}
+	clockwise := geojson.Curve{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}
+	hole := geojson.Curve{{0.25, 0.25}, {0.75, 0.25}, {0.75, 0.75}, {0.25, 0.75}, {0.25, 0.25}}
+
+	area := (&geojson.Polygon{Coords: geojson.Surface{square}}).Area()
+	holeArea := (&geojson.Polygon{Coords: geojson.Surface{hole}}).Area()
+
+	it.Then(t).Should(
+		it.True(near(area, 12_364e6, 50e6)),
+		it.True(near((&geojson.Polygon{Coords: geojson.Surface{clockwise}}).Area(), area, 1e-3)),
+		it.True(near((&geojson.Polygon{Coords: geojson.Surface{square, hole}}).Area(), area-holeArea, 1e-3)),
+		it.True(near((&geojson.Polygon{Coords: geojson.Surface{square, {{0, 0}, {1, 1}, {0, 0}}}}).Area(), area, 1e-3)),
+		it.Equal((&geojson.Polygon{}).Area(), 0.0),
+		it.Equal((&geojson.Polygon{Coords: geojson.Surface{{{0, 0}, {1, 1}, {0, 0}}}}).Area(), 0.0),
+		it.True(near((&geojson.MultiPolygon{Coords: geojson.Surfaces{{square}, {clockwise}}}).Area(), 2*area, 1e-3)),
+	)
+}
+
+func TestBearing(t *testing.T) {
+	it.Then(t).Should(
+		it.True(near(geojson.Bearing(geojson.Coord{0, 0}, geojson.Coord{0, 1}), 0.0, 1e-9)),
+		it.True(near(geojson.Bearing(geojson.Coord{0, 0}, geojson.Coord{1, 0}), 90.0, 1e-9)),
+		it.True(near(geojson.Bearing(geojson.Coord{0, 0}, geojson.Coord{0, -1}), 180.0, 1e-9)),
+		it.True(near(geojson.Bearing(geojson.Coord{0, 0}, geojson.Coord{-1, 0}), 270.0, 1e-9)),
+		it.True(near(geojson.Bearing(coordHelsinki, coordStockholm), 259.3, 0.1)),
+	)
+}
+
+func TestDestination(t *testing.T) {
+	d := geojson.Distance(coordHelsinki, coordStockholm)
+	b := geojson.Bearing(coordHelsinki, coordStockholm)
+	c := geojson.Destination(coordHelsinki, d, b)
+
+	it.Then(t).Should(
+		it.True(near(c.Lng(), coordStockholm.Lng(), 1e-6)),
+		it.True(near(c.Lat(), coordStockholm.Lat(), 1e-6)),
+		it.Equal(geojson.Destination(geojson.Coord{24.9, 60.1, 100.0}, d, b).Alt(), 100.0),
+		it.True(near(geojson.Destination(geojson.Coord{179.0, 0.0}, 222_390, 90.0).Lng(), -179.0, 1e-3)),
+		it.Equal(len(geojson.Destination(geojson.Coord{}, d, b)), 0),
+	)
+}
+
+func TestLineStringNearestPoint(t *testing.T) {
+	geo := geojson.LineString{
+		Coords: geojson.Curve{{0, 0}, {1, 0}, {1, 1}},
+	}
+
+	p, d := geo.NearestPoint(geojson.Coord{0.5, 0.1})
+	it.Then(t).Should(
+		it.True(nearCoord(p, geojson.Coord{0.5, 0.0})),
+		it.True(near(d, geojson.Distance(geojson.Coord{0.5, 0.1}, geojson.Coord{0.5, 0.0}), 1e-6)),
+	)
+
+	p, _ = geo.NearestPoint(geojson.Coord{-1.0, -1.0})
+	it.Then(t).Should(
+		it.True(nearCoord(p, geojson.Coord{0.0, 0.0})),
+	)
+
+	p, _ = geo.NearestPoint(geojson.Coord{1.5, 0.5})
+	it.Then(t).Should(
+		it.True(nearCoord(p, geojson.Coord{1.0, 0.5})),
+	)
+
+	p, d = (&geojson.LineString{Coords: geojson.Curve{{1, 1}}}).NearestPoint(geojson.Coord{1, 1})
+	it.Then(t).Should(
+		it.True(nearCoord(p, geojson.Coord{1.0, 1.0})),
+		it.Equal(d, 0.0),
+	)
+
+	p, d = (&geojson.LineString{}).NearestPoint(geojson.Coord{1, 1})
+	it.Then(t).Should(
+		it.Equal(len(p), 0),
+		it.True(math.IsInf(d, 1)),
+	)
+}
+
+func TestPolygonSignedDistance(t *testing.T) {
+	geo := &geojson.Polygon{
+		Coords: geojson.Surface{
+			{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}},
+			{{0.5, 0.5}, {1.5, 0.5}, {1.5, 1.5}, {0.5, 1.5}, {0.5, 0.5}},
+		},
+	}
+
+	inside := geo.SignedDistance(geojson.Coord{0.2, 1.0})
+	hole := geo.SignedDistance(geojson.Coord{1.0, 1.0})
+	outside := geo.SignedDistance(geojson.Coord{3.0, 1.0})
+
+	it.Then(t).Should(
+		it.True(inside < 0),
+		it.True(near(inside, -geojson.Distance(geojson.Coord{0.2, 1.0}, geojson.Coord{0.0, 1.0}), 1.0)),
+		it.True(hole > 0),
+		it.True(near(hole, geojson.Distance(geojson.Coord{1.0, 1.0}, geojson.Coord{0.5, 1.0}), 1.0)),
+		it.True(outside > 0),
+		it.True(near(outside, geojson.Distance(geojson.Coord{3.0, 1.0}, geojson.Coord{2.0, 1.0}), 1.0)),
+		it.Equal(geo.SignedDistance(geojson.Coord{2.0, 1.0}), 0.0),
+		it.True(math.IsInf((&geojson.Polygon{}).SignedDistance(geojson.Coord{1, 1}), 1)),
+	)
+}
+
+func TestLineStringDensify(t *testing.T) {
+	geo := &geojson.LineString{Coords: geojson.Curve{{0, 0}, {1, 0}, {1, 0.1}}}
+	seq := geo.Densify(10_000)
+
+	isShort := true
+	for i := 1; i < len(seq.Coords); i++ {
+		isShort = isShort && geojson.Distance(seq.Coords[i-1], seq.Coords[i]) <= 10_000
+	}
+
+	it.Then(t).Should(
+		it.Equal(len(geo.Coords), 3),
+		it.Equal(len(seq.Coords), 15),
+		it.True(isShort),
+		it.True(near(seq.Length(), geo.Length(), 1e-3)),
+		it.True(nearCoord(seq.Coords[6], geojson.Coord{0.5, 0})),
+		it.Equiv(seq.Coords[14], geojson.Coord{1, 0.1}),
+		it.Equal(len((&geojson.LineString{}).Densify(10_000).Coords), 0),
+		it.Equal(len(geo.Densify(0).Coords), 3),
+	)
+}
+
+func TestPolygonDensify(t *testing.T) {
+	geo := &geojson.Polygon{Coords: coordPolygonWithHole}
+	seq := geo.Densify(10_000)
+
+	it.Then(t).Should(
+		it.Equal(len(seq.Coords), 2),
+		it.True(len(seq.Coords[0]) > len(geo.Coords[0])),
+		it.True(len(seq.Coords[1]) > len(geo.Coords[1])),
+		it.Equiv(seq.Coords[0][0], seq.Coords[0][len(seq.Coords[0])-1]),
+		it.Equiv(seq.Coords[1][0], seq.Coords[1][len(seq.Coords[1])-1]),
+	)
+}
+
+func TestBoundingBoxAround(t *testing.T) {
+	bbox := geojson.BoundingBoxAround(coordHelsinki, 10000.0)
+	sw, ne := bbox.SouthWest(), bbox.NorthEast()
+
+	it.Then(t).Should(
+		it.True(near(geojson.Distance(coordHelsinki, geojson.Coord{coordHelsinki.Lng(), ne.Lat()}), 10000.0, 1.0)),
+		it.True(near(geojson.Distance(coordHelsinki, geojson.Coord{coordHelsinki.Lng(), sw.Lat()}), 10000.0, 1.0)),
+		it.True(bbox.Contains(geojson.Destination(coordHelsinki, 9999.0, 90.0))),
+		it.True(bbox.Contains(geojson.Destination(coordHelsinki, 9999.0, 270.0))),
+		it.True(bbox.Width() > bbox.Height()),
+	)
+
+	// circle is within the box, touching it at west and east
+	for bearing := 0.0; bearing < 360.0; bearing += 5.0 {
+		it.Then(t).Should(
+			it.True(bbox.Expand(1e-9).Contains(geojson.Destination(coordHelsinki, 10000.0, bearing))),
+		)
+	}
+
+	it.Then(t).Should(
+		it.Equiv(geojson.BoundingBoxAround(geojson.Coord{0.0, 89.95}, 10000.0)[0], -180.0),
+		it.Equiv(geojson.BoundingBoxAround(geojson.Coord{0.0, 89.95}, 10000.0)[3], 90.0),
+		it.True(geojson.BoundingBoxAround(geojson.Coord{179.99, 0.0}, 10000.0).SouthWest().Lng() > 179.0),
+		it.True(geojson.BoundingBoxAround(geojson.Coord{179.99, 0.0}, 10000.0).NorthEast().Lng() < -179.0),
+		it.Equiv(geojson.BoundingBoxAround(geojson.Coord{}, 10000.0), nil),
+	)
+}
@@ -0,0 +1,58 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// DecodeFeature reads GeoJSON feature from the stream into v, the type T
+// is expected to implement GeoJSON codec.
+//
+//	var city City
+//	geojson.DecodeFeature(r.Body, &city)
+func DecodeFeature[T any](r io.Reader, v *T) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+// EncodeFeature writes GeoJSON feature to the stream, followed by newline.
+// The type T is expected to implement GeoJSON codec.
+func EncodeFeature[T any](w io.Writer, v T) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// DecodeCollection reads GeoJSON feature collection from the stream.
+func DecodeCollection[T interface{ BoundingBox() BoundingBox }](r io.Reader, c *Collection[T]) error {
+	return json.NewDecoder(r).Decode(c)
+}
+
+// EncodeCollection writes GeoJSON feature collection to the stream,
+// followed by newline.
+func EncodeCollection[T interface{ BoundingBox() BoundingBox }](w io.Writer, c Collection[T]) error {
+	return json.NewEncoder(w).Encode(&c)
+}
+
+// MarshalIndent encodes value to indented GeoJSON. The value is encoded by
+// its own codec, members are emitted in the stable order defined by
+// feature and collection encoders.
+func MarshalIndent(v any, prefix, indent string) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, b, prefix, indent); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
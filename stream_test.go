@@ -0,0 +1,103 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestStreamFeature(t *testing.T) {
+	var city GeoJsonCity
+	err := geojson.DecodeFeature(strings.NewReader(featurePoint), &city)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(city.Name, "Helsinki"),
+		it.Like(city.Geometry, &geojson.Point{geojson.Coord{102.0, 0.5}}),
+	)
+
+	buf := &bytes.Buffer{}
+	it.Then(t).Should(
+		it.Nil(geojson.EncodeFeature(buf, city)),
+	)
+
+	var c GeoJsonCity
+	err = geojson.DecodeFeature(buf, &c)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(c, city),
+	)
+}
+
+func TestStreamCollection(t *testing.T) {
+	buf := &bytes.Buffer{}
+	it.Then(t).Should(
+		it.Nil(geojson.EncodeCollection(buf, testCities())),
+		it.String(buf.String()).Contain(`"type":"FeatureCollection"`),
+	)
+
+	var seq geojson.Collection[GeoJsonCity]
+	err := geojson.DecodeCollection(buf, &seq)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(seq.Len(), 3),
+		it.Equal(seq.Features[1].Name, "Helsinki"),
+		it.Equiv(seq.BBox, testCities().BBox),
+	)
+
+	err = geojson.DecodeCollection(strings.NewReader(featurePoint), &seq)
+	it.Then(t).Should(
+		it.Equal(err, error(geojson.ErrUnsupportedType)),
+	)
+}
+
+func TestMarshalIndent(t *testing.T) {
+	fea := geojson.NewLineString("city:helsinki",
+		geojson.Curve{{24.9384, 60.1699}, {24.9415, 60.1725}},
+	)
+	fea.Foreign = map[string]json.RawMessage{"zoom": []byte(`10`), "layer": []byte(`"city"`)}
+
+	data, err := geojson.MarshalIndent(GeoJsonCity{Feature: fea, City: City{Name: "Helsinki"}}, "", "  ")
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(string(data), `{
+  "type": "Feature",
+  "id": "[city:helsinki]",
+  "bbox": [
+    24.9384,
+    60.1699,
+    24.9415,
+    60.1725
+  ],
+  "geometry": {
+    "type": "LineString",
+    "coordinates": [
+      [
+        24.9384,
+        60.1699
+      ],
+      [
+        24.9415,
+        60.1725
+      ]
+    ]
+  },
+  "properties": {
+    "name": "Helsinki"
+  },
+  "layer": "city",
+  "zoom": 10
+}`),
+	)
+}
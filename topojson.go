@@ -0,0 +1,263 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/fogfish/curie/v2"
+)
+
+const TYPE_TOPOLOGY = "Topology"
+
+// DecodeTopoJSON expands TopoJSON topology into features. Arcs shared by
+// geometries are stitched into absolute positions, the quantized topology
+// is decoded using its transform. Members of GeometryCollection object
+// become features, other objects become a single feature each. Objects
+// are expanded in the order of their names.
+//
+// Properties of features are retained as raw JSON, use Feature.Unmarshal
+// to decode them. Identifiers are retained as-is, either string or number.
+func DecodeTopoJSON(b []byte) ([]Feature, error) {
+	var topo struct {
+		Type      string                     `json:"type"`
+		Transform *topoTransform             `json:"transform,omitempty"`
+		Arcs      []Curve                    `json:"arcs"`
+		Objects   map[string]json.RawMessage `json:"objects"`
+	}
+
+	if err := json.Unmarshal(b, &topo); err != nil {
+		return nil, err
+	}
+
+	if topo.Type != TYPE_TOPOLOGY {
+		return nil, ErrUnsupportedType
+	}
+
+	dec := topoDecoder{transform: topo.Transform, arcs: topo.Arcs}
+	dec.decodeArcs()
+
+	names := make([]string, 0, len(topo.Objects))
+	for name := range topo.Objects {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	seq := []Feature{}
+	for _, name := range names {
+		var obj topoGeometry
+		if err := json.Unmarshal(topo.Objects[name], &obj); err != nil {
+			return nil, err
+		}
+
+		members := []topoGeometry{obj}
+		if obj.Type == typeGeometryCollection {
+			members = obj.Geometries
+		}
+
+		for _, x := range members {
+			fea, err := dec.feature(x)
+			if err != nil {
+				return nil, fmt.Errorf("object %s: %w", name, err)
+			}
+			seq = append(seq, fea)
+		}
+	}
+
+	return seq, nil
+}
+
+// quantization of TopoJSON
+type topoTransform struct {
+	Scale     [2]float64 `json:"scale"`
+	Translate [2]float64 `json:"translate"`
+}
+
+type topoGeometry struct {
+	Type        geometryType    `json:"type"`
+	ID          json.RawMessage `json:"id,omitempty"`
+	BBox        BoundingBox     `json:"bbox,omitempty"`
+	Properties  json.RawMessage `json:"properties,omitempty"`
+	Arcs        json.RawMessage `json:"arcs,omitempty"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+	Geometries  []topoGeometry  `json:"geometries,omitempty"`
+}
+
+type topoDecoder struct {
+	transform *topoTransform
+	arcs      []Curve
+}
+
+// decode delta-encoded arcs of quantized topology into absolute positions
+func (dec *topoDecoder) decodeArcs() {
+	if dec.transform == nil {
+		return
+	}
+
+	for _, arc := range dec.arcs {
+		x, y := 0.0, 0.0
+		for i, c := range arc {
+			if len(c) < 2 {
+				continue
+			}
+			x, y = x+c[0], y+c[1]
+			arc[i] = append(Coord{
+				x*dec.transform.Scale[0] + dec.transform.Translate[0],
+				y*dec.transform.Scale[1] + dec.transform.Translate[1],
+			}, c[2:]...)
+		}
+	}
+}
+
+// decode quantized position of point
+func (dec *topoDecoder) position(c Coord) Coord {
+	if dec.transform == nil || len(c) < 2 {
+		return c
+	}
+
+	return append(Coord{
+		c[0]*dec.transform.Scale[0] + dec.transform.Translate[0],
+		c[1]*dec.transform.Scale[1] + dec.transform.Translate[1],
+	}, c[2:]...)
+}
+
+// line stitched from arcs, negative index refers to reversed arc ^i
+func (dec *topoDecoder) line(arcs []int) (Curve, error) {
+	seq := Curve{}
+	for _, i := range arcs {
+		k := i
+		if k < 0 {
+			k = ^k
+		}
+		if k >= len(dec.arcs) {
+			return nil, fmt.Errorf("%w: arc %d is not defined", ErrInvalidTopoJSON, i)
+		}
+
+		arc := dec.arcs[k]
+		if i < 0 {
+			arc = reverseOf(arc)
+		}
+
+		// Note: consequent arcs share the end position
+		if len(seq) > 0 && len(arc) > 0 {
+			arc = arc[1:]
+		}
+		seq = append(seq, arc...)
+	}
+	return seq, nil
+}
+
+func (dec *topoDecoder) surface(arcs [][]int) (Surface, error) {
+	seq := make(Surface, len(arcs))
+	for i, ring := range arcs {
+		curve, err := dec.line(ring)
+		if err != nil {
+			return nil, err
+		}
+		seq[i] = curve
+	}
+	return seq, nil
+}
+
+func (dec *topoDecoder) feature(obj topoGeometry) (Feature, error) {
+	geo, err := dec.geometry(obj)
+	if err != nil {
+		return Feature{}, err
+	}
+
+	fea := Feature{BBox: obj.BBox, Geometry: geo, Properties: obj.Properties}
+
+	if len(obj.ID) > 0 && obj.ID[0] == '"' {
+		var id string
+		if err := json.Unmarshal(obj.ID, &id); err != nil {
+			return Feature{}, err
+		}
+		fea.SetID(curie.IRI(id))
+		return fea, nil
+	}
+
+	if err := fea.decodeID(obj.ID); err != nil {
+		return Feature{}, err
+	}
+	return fea, nil
+}
+
+func (dec *topoDecoder) geometry(obj topoGeometry) (Geometry, error) {
+	switch obj.Type {
+	case "":
+		// Note: TopoJSON defines null type for geometry without positions
+		return nil, nil
+	case typePoint:
+		var c Coord
+		if err := json.Unmarshal(obj.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		return &Point{Coords: dec.position(c)}, nil
+	case typeMultiPoint:
+		var seq Curve
+		if err := json.Unmarshal(obj.Coordinates, &seq); err != nil {
+			return nil, err
+		}
+		for i, c := range seq {
+			seq[i] = dec.position(c)
+		}
+		return &MultiPoint{Coords: seq}, nil
+	case typeLineString:
+		var arcs []int
+		if err := json.Unmarshal(obj.Arcs, &arcs); err != nil {
+			return nil, err
+		}
+		seq, err := dec.line(arcs)
+		return &LineString{Coords: seq}, err
+	case typeMultiLineString:
+		var arcs [][]int
+		if err := json.Unmarshal(obj.Arcs, &arcs); err != nil {
+			return nil, err
+		}
+		seq, err := dec.surface(arcs)
+		return &MultiLineString{Coords: seq}, err
+	case typePolygon:
+		var arcs [][]int
+		if err := json.Unmarshal(obj.Arcs, &arcs); err != nil {
+			return nil, err
+		}
+		seq, err := dec.surface(arcs)
+		return &Polygon{Coords: seq}, err
+	case typeMultiPolygon:
+		var arcs [][][]int
+		if err := json.Unmarshal(obj.Arcs, &arcs); err != nil {
+			return nil, err
+		}
+		seq := make(Surfaces, len(arcs))
+		for i, poly := range arcs {
+			surface, err := dec.surface(poly)
+			if err != nil {
+				return nil, err
+			}
+			seq[i] = surface
+		}
+		return &MultiPolygon{Coords: seq}, nil
+	case typeGeometryCollection:
+		seq := make([]Geometry, 0, len(obj.Geometries))
+		for _, x := range obj.Geometries {
+			geo, err := dec.geometry(x)
+			if err != nil {
+				return nil, err
+			}
+			if geo != nil {
+				seq = append(seq, geo)
+			}
+		}
+		return &GeometryCollection{Geometries: seq}, nil
+	default:
+		return nil, fmt.Errorf("type %s is not supported as TopoJSON geometry: %w: %w", obj.Type, ErrUnknownGeometryType, ErrUnsupportedType)
+	}
+}
@@ -0,0 +1,141 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+// example of TopoJSON specification
+const topoExample = `{
+	"type": "Topology",
+	"transform": {
+		"scale": [0.0005000500050005, 0.00010001000100010001],
+		"translate": [100, 0]
+	},
+	"objects": {
+		"example": {
+			"type": "GeometryCollection",
+			"geometries": [
+				{"type": "Point", "id": "FIN", "properties": {"name": "Helsinki"}, "coordinates": [4000, 5000]},
+				{"type": "LineString", "id": 2, "properties": {"prop0": "value0"}, "arcs": [0]},
+				{"type": "Polygon", "properties": {"prop0": "value0"}, "arcs": [[-2]]}
+			]
+		}
+	},
+	"arcs": [
+		[[4000, 0], [1999, 9999], [2000, -9999], [2000, 9999]],
+		[[0, 0], [0, 9999], [2000, 0], [0, -9999], [-2000, 0]]
+	]
+}`
+
+func nearCurve(a, b geojson.Curve) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for k := range a[i] {
+			if !near(a[i][k], b[i][k], 1e-3) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestDecodeTopoJSON(t *testing.T) {
+	seq, err := geojson.DecodeTopoJSON([]byte(topoExample))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(len(seq), 3),
+		it.TypeOf[*geojson.Point](seq[0].Geometry),
+		it.TypeOf[*geojson.LineString](seq[1].Geometry),
+		it.TypeOf[*geojson.Polygon](seq[2].Geometry),
+	)
+
+	var city City
+	num, isNum := seq[1].NumericID()
+	it.Then(t).Should(
+		it.Equal(seq[0].ID, "FIN"),
+		it.Nil(seq[0].Unmarshal(&city)),
+		it.Equal(city.Name, "Helsinki"),
+		it.True(isNum),
+		it.Equal(num, 2.0),
+		it.True(!seq[2].HasID()),
+	)
+
+	pt := seq[0].Geometry.(*geojson.Point)
+	line := seq[1].Geometry.(*geojson.LineString)
+	poly := seq[2].Geometry.(*geojson.Polygon)
+	it.Then(t).Should(
+		it.True(nearCurve(geojson.Curve{pt.Coords}, geojson.Curve{{102.0, 0.5}})),
+		it.True(nearCurve(line.Coords, geojson.Curve{{102, 0}, {103, 1}, {104, 0}, {105, 1}})),
+		it.True(nearCurve(poly.Coords[0], geojson.Curve{{100, 0}, {101, 0}, {101, 1}, {100, 1}, {100, 0}})),
+	)
+}
+
+func TestDecodeTopoJSONSharedArcs(t *testing.T) {
+	// two squares sharing the edge, arcs are not quantized
+	topo := `{
+		"type": "Topology",
+		"objects": {
+			"b": {"type": "Polygon", "id": "east", "arcs": [[-1, 2]]},
+			"a": {"type": "Polygon", "id": "west", "arcs": [[0, 1]]},
+			"c": {"type": "MultiLineString", "arcs": [[0], [1]]},
+			"d": {"type": null}
+		},
+		"arcs": [
+			[[1, 0], [1, 1]],
+			[[1, 1], [0, 1], [0, 0], [1, 0]],
+			[[1, 0], [2, 0], [2, 1], [1, 1]]
+		]
+	}`
+
+	seq, err := geojson.DecodeTopoJSON([]byte(topo))
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(len(seq), 4),
+		it.Equal(seq[0].ID, "west"),
+		it.Equal(seq[1].ID, "east"),
+		it.Nil(seq[3].Geometry),
+	)
+
+	west := seq[0].Geometry.(*geojson.Polygon)
+	east := seq[1].Geometry.(*geojson.Polygon)
+	lines := seq[2].Geometry.(*geojson.MultiLineString)
+	it.Then(t).Should(
+		it.Equiv(west.Coords, geojson.Surface{{{1, 0}, {1, 1}, {0, 1}, {0, 0}, {1, 0}}}),
+		it.Equiv(east.Coords, geojson.Surface{{{1, 1}, {1, 0}, {2, 0}, {2, 1}, {1, 1}}}),
+		it.Equal(len(lines.Coords), 2),
+	)
+}
+
+func TestDecodeTopoJSONInvalid(t *testing.T) {
+	_, err := geojson.DecodeTopoJSON([]byte(`{"type":"FeatureCollection","features":[]}`))
+	it.Then(t).Should(
+		it.Equal(err, error(geojson.ErrUnsupportedType)),
+	)
+
+	_, err = geojson.DecodeTopoJSON([]byte(`{"type":"Topology","objects":{"a":{"type":"LineString","arcs":[5]}},"arcs":[]}`))
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrInvalidTopoJSON)),
+	)
+
+	_, err = geojson.DecodeTopoJSON([]byte(`{"type":"Topology","objects":{"a":{"type":"Sphere"}},"arcs":[]}`))
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrUnknownGeometryType)),
+	)
+}
@@ -0,0 +1,290 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+)
+
+// vertex is a horizontal position used as a key of topology
+type vertex [2]float64
+
+func vertexOf(c Coord) vertex { return vertex{c.Lng(), c.Lat()} }
+
+func (a vertex) less(b vertex) bool {
+	return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+}
+
+// edge is an undirected segment between vertices
+type edge [2]vertex
+
+func edgeOf(a, b Coord) edge {
+	x, y := vertexOf(a), vertexOf(b)
+	if y.less(x) {
+		x, y = y, x
+	}
+	return edge{x, y}
+}
+
+// topology of polygon rings, shared edges are owned by multiple rings.
+// Rings are split into arcs at junctions, positions where the set of
+// owners changes. Arcs shared by rings are simplified once.
+type topology struct {
+	tolerance float64
+	rings     []Curve
+	edges     map[edge][]int
+	vertices  map[vertex][]int
+	arcs      [][]Curve
+	cache     map[string]Curve
+}
+
+func newTopology(tolerance float64) *topology {
+	return &topology{
+		tolerance: tolerance,
+		edges:     map[edge][]int{},
+		vertices:  map[vertex][]int{},
+		cache:     map[string]Curve{},
+	}
+}
+
+func owner(seq []int, id int) []int {
+	if len(seq) > 0 && seq[len(seq)-1] == id {
+		return seq
+	}
+	return append(seq, id)
+}
+
+// add rings of the surface to topology, it returns identity of rings.
+func (topo *topology) add(surface Surface) []int {
+	ids := make([]int, len(surface))
+	for i, ring := range surface {
+		id := len(topo.rings)
+		topo.rings = append(topo.rings, ring)
+		ids[i] = id
+
+		if len(ring) < 4 {
+			continue
+		}
+
+		for k := 0; k < len(ring)-1; k++ {
+			e := edgeOf(ring[k], ring[k+1])
+			topo.edges[e] = owner(topo.edges[e], id)
+			v := vertexOf(ring[k])
+			topo.vertices[v] = owner(topo.vertices[v], id)
+		}
+	}
+	return ids
+}
+
+// split rings into arcs at junctions
+func (topo *topology) split() {
+	topo.arcs = make([][]Curve, len(topo.rings))
+
+	for id, ring := range topo.rings {
+		if len(ring) < 4 {
+			continue
+		}
+
+		n := len(ring) - 1
+		junctions := []int{}
+		for k := 0; k < n; k++ {
+			in := topo.edges[edgeOf(ring[(k+n-1)%n], ring[k])]
+			out := topo.edges[edgeOf(ring[k], ring[k+1])]
+			if !slices.Equal(in, out) || !slices.Equal(topo.vertices[vertexOf(ring[k])], out) {
+				junctions = append(junctions, k)
+			}
+		}
+
+		if len(junctions) == 0 {
+			// Note: ring shared as a whole starts at the smallest vertex, so
+			//       that the ring is simplified identically by all owners.
+			start := 0
+			if len(topo.edges[edgeOf(ring[0], ring[1])]) > 1 {
+				for k := 1; k < n; k++ {
+					if vertexOf(ring[k]).less(vertexOf(ring[start])) {
+						start = k
+					}
+				}
+			}
+			topo.arcs[id] = []Curve{rotate(ring, start)}
+			continue
+		}
+
+		rotated := rotate(ring, junctions[0])
+		arcs := []Curve{}
+		for i, k := range junctions {
+			a := k - junctions[0]
+			b := n
+			if i+1 < len(junctions) {
+				b = junctions[i+1] - junctions[0]
+			}
+			arcs = append(arcs, rotated[a:b+1])
+		}
+		topo.arcs[id] = arcs
+	}
+}
+
+// rotate closed ring to start at position k
+func rotate(ring Curve, k int) Curve {
+	if k == 0 {
+		return ring
+	}
+
+	n := len(ring) - 1
+	seq := make(Curve, 0, len(ring))
+	seq = append(seq, ring[k:n]...)
+	seq = append(seq, ring[:k+1]...)
+	return seq
+}
+
+// simplify arc, the arc is simplified in canonical direction so that
+// owners of shared arc obtain identical positions.
+func (topo *topology) simplify(arc Curve, closed bool) Curve {
+	canonical, reversed := arc, false
+	if rev := reverseOf(arc); lessCurve(rev, arc) {
+		canonical, reversed = rev, true
+	}
+
+	key := keyOf(canonical)
+	seq, has := topo.cache[key]
+	if !has {
+		switch {
+		case closed:
+			seq = canonical.simplifyRing(topo.tolerance)
+		default:
+			// Note: arc of ring with less than three arcs retains intermediate
+			//       position, otherwise the ring collapses.
+			keep := false
+			for _, id := range topo.edges[edgeOf(arc[0], arc[1])] {
+				keep = keep || len(topo.arcs[id]) < 3
+			}
+			seq = canonical.simplify(topo.tolerance, keep)
+		}
+		topo.cache[key] = seq
+	}
+
+	if reversed {
+		return reverseOf(seq)
+	}
+	return seq
+}
+
+// ring returns simplified ring
+func (topo *topology) ring(id int) Curve {
+	arcs := topo.arcs[id]
+	if arcs == nil {
+		return transformCurve(topo.rings[id], clone)
+	}
+
+	if len(arcs) == 1 {
+		return transformCurve(topo.simplify(arcs[0], true), clone)
+	}
+
+	ring := Curve{}
+	for i, arc := range arcs {
+		seq := topo.simplify(arc, false)
+		if i > 0 {
+			seq = seq[1:]
+		}
+		ring = append(ring, transformCurve(seq, clone)...)
+	}
+	return ring
+}
+
+func (topo *topology) surface(ids []int) Surface {
+	surface := make(Surface, len(ids))
+	for i, id := range ids {
+		surface[i] = topo.ring(id)
+	}
+	return surface
+}
+
+func reverseOf(seq Curve) Curve {
+	rev := make(Curve, len(seq))
+	for i, x := range seq {
+		rev[len(seq)-1-i] = x
+	}
+	return rev
+}
+
+func lessCurve(a, b Curve) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, y := vertexOf(a[i]), vertexOf(b[i])
+		if x != y {
+			return x.less(y)
+		}
+	}
+	return len(a) < len(b)
+}
+
+func keyOf(seq Curve) string {
+	b := make([]byte, 0, len(seq)*16)
+	for _, x := range seq {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(x.Lng()))
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(x.Lat()))
+	}
+	return string(b)
+}
+
+// SimplifyTopology returns new collection with simplified geometries of
+// features. Polygons are simplified preserving topology, edges shared by
+// adjacent polygons are simplified identically so that neighbours remain
+// coincident. Line strings are simplified independently, see Simplify.
+// The tolerance is defined in coordinate units (degrees).
+//
+// Only features that embed Feature by value are simplified, others are
+// retained as-is.
+func (c Collection[T]) SimplifyTopology(tolerance float64) Collection[T] {
+	seq := make([]T, len(c.Features))
+	copy(seq, c.Features)
+
+	topo := newTopology(tolerance)
+	build := []func(){}
+
+	for i := range seq {
+		f, ok := any(&seq[i]).(interface{ feature() *Feature })
+		if !ok {
+			continue
+		}
+
+		fea := f.feature()
+		switch geo := fea.Geometry.(type) {
+		case *LineString:
+			fea.Geometry = geo.Simplify(tolerance)
+		case *MultiLineString:
+			fea.Geometry = geo.Simplify(tolerance)
+		case *Polygon:
+			ids := topo.add(geo.Coords)
+			build = append(build, func() {
+				fea.Geometry = &Polygon{Coords: topo.surface(ids)}
+			})
+		case *MultiPolygon:
+			ids := make([][]int, len(geo.Coords))
+			for k, surface := range geo.Coords {
+				ids[k] = topo.add(surface)
+			}
+			build = append(build, func() {
+				surfaces := make(Surfaces, len(ids))
+				for k, x := range ids {
+					surfaces[k] = topo.surface(x)
+				}
+				fea.Geometry = &MultiPolygon{Coords: surfaces}
+			})
+		}
+	}
+
+	topo.split()
+	for _, f := range build {
+		f()
+	}
+
+	return Collection[T]{CRS: c.CRS, Features: seq}
+}
@@ -0,0 +1,114 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestSimplifyTopology(t *testing.T) {
+	// jagged edge shared by both polygons
+	shared := geojson.Curve{{1.0, 0.0}, {1.004, 0.2}, {0.996, 0.4}, {1.02, 0.5}, {0.996, 0.6}, {1.0, 1.0}}
+
+	west := geojson.Curve{{0.0, 0.0}}
+	west = append(west, shared...)
+	west = append(west, geojson.Coord{0.0, 1.0}, geojson.Coord{0.0, 0.0})
+
+	east := geojson.Curve{{2.0, 0.0}, {2.0, 1.0}}
+	for i := len(shared) - 1; i >= 0; i-- {
+		east = append(east, shared[i])
+	}
+	east = append(east, geojson.Coord{2.0, 0.0})
+
+	seq := geojson.Collection[geojson.Feature]{
+		Features: []geojson.Feature{
+			geojson.NewPolygon("geo:west", geojson.Surface{west}),
+			geojson.NewMultiPolygon("geo:east", geojson.Surface{east}),
+			geojson.NewLineString("geo:line", geojson.Curve{{0.0, 2.0}, {1.0, 2.001}, {2.0, 2.0}}),
+			geojson.NewPoint("geo:point", geojson.Coord{0.5, 0.5}),
+		},
+	}
+
+	simple := seq.SimplifyTopology(0.03)
+
+	w := simple.Features[0].Geometry.(*geojson.Polygon).Coords[0]
+	e := simple.Features[1].Geometry.(*geojson.MultiPolygon).Coords[0][0]
+
+	// shared positions of both rings, in the order of west ring
+	common := func(a, b geojson.Curve) geojson.Curve {
+		seq := geojson.Curve{}
+		for _, x := range a[:len(a)-1] {
+			for _, y := range b {
+				if x.Lng() == y.Lng() && x.Lat() == y.Lat() {
+					seq = append(seq, x)
+					break
+				}
+			}
+		}
+		return seq
+	}
+
+	it.Then(t).Should(
+		it.Equiv(common(w, e), geojson.Curve{{1.0, 0.0}, {1.02, 0.5}, {1.0, 1.0}}),
+		it.Equiv(common(e, w), geojson.Curve{{1.0, 1.0}, {1.02, 0.5}, {1.0, 0.0}}),
+		it.Equal(len(w), 6),
+		it.Equal(len(e), 6),
+		it.Equiv(simple.Features[2].Geometry.(*geojson.LineString).Coords, geojson.Curve{{0.0, 2.0}, {2.0, 2.0}}),
+		it.Equiv(simple.Features[3].Geometry, seq.Features[3].Geometry),
+		// source collection is not mutated
+		it.Equal(len(seq.Features[0].Geometry.(*geojson.Polygon).Coords[0]), len(west)),
+	)
+}
+
+func TestSimplifyTopologyIsolated(t *testing.T) {
+	geo := &geojson.Polygon{Coords: geojson.Surface{
+		{{0.0, 0.0}, {0.5, 0.001}, {1.0, 0.0}, {1.001, 0.5}, {1.0, 1.0}, {0.0, 1.0}, {0.0, 0.0}},
+	}}
+	seq := geojson.Collection[GeoJsonCity]{
+		Features: []GeoJsonCity{
+			{Feature: geojson.New("geo:square", geo), City: City{Name: "Square"}},
+		},
+	}
+
+	simple := seq.SimplifyTopology(0.01)
+	it.Then(t).Should(
+		it.Equal(simple.Features[0].Name, "Square"),
+		it.Equiv(simple.Features[0].Geometry, geojson.Geometry(geo.Simplify(0.01))),
+	)
+}
+
+func TestSimplifyTopologyCoincident(t *testing.T) {
+	ring := geojson.Curve{{0.0, 0.0}, {0.5, 0.001}, {1.0, 0.0}, {1.0, 1.0}, {0.0, 1.0}, {0.0, 0.0}}
+	rev := geojson.Curve{{1.0, 1.0}, {1.0, 0.0}, {0.5, 0.001}, {0.0, 0.0}, {0.0, 1.0}, {1.0, 1.0}}
+
+	seq := geojson.Collection[geojson.Feature]{
+		Features: []geojson.Feature{
+			geojson.NewPolygon("geo:a", geojson.Surface{ring}),
+			geojson.NewPolygon("geo:b", geojson.Surface{rev}),
+		},
+	}
+
+	simple := seq.SimplifyTopology(0.01)
+	a := simple.Features[0].Geometry.(*geojson.Polygon).Coords[0]
+	b := simple.Features[1].Geometry.(*geojson.Polygon).Coords[0]
+
+	it.Then(t).Should(
+		it.Equal(len(a), 5),
+		it.Equal(len(b), 5),
+	)
+
+	for i := range a {
+		it.Then(t).Should(
+			it.Equiv(a[i], b[len(b)-1-i]),
+		)
+	}
+}
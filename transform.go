@@ -0,0 +1,112 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+// transform builds new geometry applying function to each position
+func transform(geo Geometry, f func(Coord) Coord) Geometry {
+	switch v := geo.(type) {
+	case *Point:
+		if v.Coords == nil {
+			return &Point{}
+		}
+		return &Point{Coords: f(v.Coords)}
+	case *MultiPoint:
+		return &MultiPoint{Coords: transformCurve(v.Coords, f)}
+	case *LineString:
+		return &LineString{Coords: transformCurve(v.Coords, f)}
+	case *MultiLineString:
+		return &MultiLineString{Coords: transformSurface(v.Coords, f)}
+	case *Polygon:
+		return &Polygon{Coords: transformSurface(v.Coords, f)}
+	case *MultiPolygon:
+		if v.Coords == nil {
+			return &MultiPolygon{}
+		}
+		seq := make(Surfaces, len(v.Coords))
+		for i, x := range v.Coords {
+			seq[i] = transformSurface(x, f)
+		}
+		return &MultiPolygon{Coords: seq}
+	case RawGeometry:
+		x, err := v.decode()
+		if err != nil {
+			return v
+		}
+		return transform(x, f)
+	case *GeometryCollection:
+		if v.Geometries == nil {
+			return &GeometryCollection{}
+		}
+		seq := make([]Geometry, len(v.Geometries))
+		for i, x := range v.Geometries {
+			seq[i] = transform(x, f)
+		}
+		return &GeometryCollection{Geometries: seq}
+	default:
+		return geo
+	}
+}
+
+func transformCurve(seq Curve, f func(Coord) Coord) Curve {
+	if seq == nil {
+		return nil
+	}
+
+	curve := make(Curve, len(seq))
+	for i, x := range seq {
+		curve[i] = f(x)
+	}
+	return curve
+}
+
+func transformSurface(seq Surface, f func(Coord) Coord) Surface {
+	if seq == nil {
+		return nil
+	}
+
+	surface := make(Surface, len(seq))
+	for i, x := range seq {
+		surface[i] = transformCurve(x, f)
+	}
+	return surface
+}
+
+// Clone returns deep copy of geometry, the positions are not shared with
+// the original one.
+func Clone(geo Geometry) Geometry {
+	return transform(geo, clone)
+}
+
+// FlipCoordinates returns new geometry with swapped lng, lat elements of
+// each position, see Coord.Flip.
+func FlipCoordinates(geo Geometry) Geometry {
+	return transform(geo, Coord.Flip)
+}
+
+// Collapse returns new geometry where single-part MultiPoint, MultiLineString
+// and MultiPolygon are replaced with equivalent Point, LineString and Polygon.
+// Other geometries are copied as-is.
+func Collapse(geo Geometry) Geometry {
+	switch v := geo.(type) {
+	case *MultiPoint:
+		if len(v.Coords) == 1 {
+			return &Point{Coords: clone(v.Coords[0])}
+		}
+	case *MultiLineString:
+		if len(v.Coords) == 1 {
+			return &LineString{Coords: transformCurve(v.Coords[0], clone)}
+		}
+	case *MultiPolygon:
+		if len(v.Coords) == 1 {
+			return &Polygon{Coords: transformSurface(v.Coords[0], clone)}
+		}
+	}
+
+	return transform(geo, clone)
+}
@@ -0,0 +1,171 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestFlip(t *testing.T) {
+	c := geojson.Coord{60.1699, 24.9384, 10.0}
+
+	it.Then(t).Should(
+		it.Seq(c.Flip()).Equal(24.9384, 60.1699, 10.0),
+		it.Seq(c).Equal(60.1699, 24.9384, 10.0),
+		it.Equal(len(geojson.Coord{}.Flip()), 0),
+	)
+}
+
+func TestFlipCoordinates(t *testing.T) {
+	geo := &geojson.Polygon{
+		Coords: geojson.Surface{{{0, 10}, {0, 11}, {1, 11}, {0, 10}}},
+	}
+
+	it.Then(t).Should(
+		it.Equiv(geojson.FlipCoordinates(geo), geojson.Geometry(&geojson.Polygon{
+			Coords: geojson.Surface{{{10, 0}, {11, 0}, {11, 1}, {10, 0}}},
+		})),
+		it.Equiv(geo.Coords[0][0], geojson.Coord{0, 10}),
+		it.Equiv(
+			geojson.FlipCoordinates(&geojson.GeometryCollection{
+				Geometries: []geojson.Geometry{&geojson.Point{Coords: geojson.Coord{60, 20}}},
+			}),
+			geojson.Geometry(&geojson.GeometryCollection{
+				Geometries: []geojson.Geometry{&geojson.Point{Coords: geojson.Coord{20, 60}}},
+			}),
+		),
+	)
+}
+
+func TestCollapse(t *testing.T) {
+	multi := &geojson.MultiPolygon{Coords: geojson.Surfaces{coordPolygon}}
+	geo := geojson.Collapse(multi)
+	geo.(*geojson.Polygon).Coords[0][0][0] = -1.0
+
+	it.Then(t).Should(
+		it.Equiv(geojson.Collapse(multi), geojson.Geometry(&geojson.Polygon{Coords: coordPolygon})),
+		it.Equal(multi.Coords[0][0][0][0], coordPolygon[0][0][0]),
+		it.Equiv(
+			geojson.Collapse(&geojson.MultiPoint{Coords: geojson.Curve{coordPoint}}),
+			geojson.Geometry(&geojson.Point{Coords: coordPoint}),
+		),
+		it.Equiv(
+			geojson.Collapse(&geojson.MultiLineString{Coords: geojson.Surface{coordLineString}}),
+			geojson.Geometry(&geojson.LineString{Coords: coordLineString}),
+		),
+		it.Equiv(
+			geojson.Collapse(&geojson.MultiPolygon{Coords: coordMultiPolygon}),
+			geojson.Geometry(&geojson.MultiPolygon{Coords: coordMultiPolygon}),
+		),
+		it.Equiv(
+			geojson.Collapse(&geojson.MultiPoint{Coords: coordMultiPoint}),
+			geojson.Geometry(&geojson.MultiPoint{Coords: coordMultiPoint}),
+		),
+	)
+}
+
+func TestSnap(t *testing.T) {
+	t.Run("Point", func(t *testing.T) {
+		geo := geojson.Snap(&geojson.Point{Coords: geojson.Coord{24.94, 60.17, 10.3}}, 0.5)
+		it.Then(t).Should(
+			it.Equiv(geo, geojson.Geometry(&geojson.Point{Coords: geojson.Coord{25.0, 60.0, 10.3}})),
+		)
+	})
+
+	t.Run("LineString", func(t *testing.T) {
+		geo := geojson.Snap(&geojson.LineString{Coords: geojson.Curve{{0.1, 0.1}, {0.2, 0.2}, {1.1, 0.9}, {1.9, 2.1}}}, 1.0)
+		it.Then(t).Should(
+			it.Equiv(geo, geojson.Geometry(&geojson.LineString{Coords: geojson.Curve{{0.0, 0.0}, {1.0, 1.0}, {2.0, 2.0}}})),
+		)
+	})
+
+	t.Run("Polygon", func(t *testing.T) {
+		ring := geojson.Curve{{0.1, 0.1}, {10.1, 0.2}, {10.2, 0.3}, {9.9, 10.1}, {0.1, 9.8}, {0.1, 0.1}}
+		hole := geojson.Curve{{4.1, 4.1}, {4.2, 4.3}, {4.3, 4.2}, {4.1, 4.1}}
+		geo := geojson.Snap(&geojson.Polygon{Coords: geojson.Surface{ring, hole}}, 1.0)
+		it.Then(t).Should(
+			it.Equiv(geo, geojson.Geometry(&geojson.Polygon{
+				Coords: geojson.Surface{{{0.0, 0.0}, {10.0, 0.0}, {10.0, 10.0}, {0.0, 10.0}, {0.0, 0.0}}},
+			})),
+		)
+	})
+
+	t.Run("Degenerated", func(t *testing.T) {
+		tiny := geojson.Curve{{0.1, 0.1}, {0.2, 0.1}, {0.2, 0.2}, {0.1, 0.1}}
+		square := geojson.Curve{{0.0, 0.0}, {5.0, 0.0}, {5.0, 5.0}, {0.0, 0.0}}
+		it.Then(t).Should(
+			it.Nil(geojson.Snap(&geojson.LineString{Coords: geojson.Curve{{0.1, 0.1}, {0.2, 0.2}}}, 1.0)),
+			it.Nil(geojson.Snap(&geojson.Polygon{Coords: geojson.Surface{tiny}}, 1.0)),
+			it.Nil(geojson.Snap(&geojson.GeometryCollection{
+				Geometries: []geojson.Geometry{&geojson.Polygon{Coords: geojson.Surface{tiny}}},
+			}, 1.0)),
+			it.Equiv(
+				geojson.Snap(&geojson.MultiPolygon{Coords: geojson.Surfaces{{tiny}, {square}}}, 1.0),
+				geojson.Geometry(&geojson.MultiPolygon{Coords: geojson.Surfaces{{square}}}),
+			),
+		)
+	})
+
+	t.Run("Immutable", func(t *testing.T) {
+		geo := &geojson.LineString{Coords: geojson.Curve{{0.1, 0.1}, {1.1, 0.9}}}
+		geojson.Snap(geo, 1.0)
+		it.Then(t).Should(
+			it.Equiv(geo.Coords, geojson.Curve{{0.1, 0.1}, {1.1, 0.9}}),
+			it.Equiv(geojson.Snap(geo, 0), geojson.Geometry(geo)),
+		)
+	})
+}
+
+func TestClone(t *testing.T) {
+	for _, geo := range []geojson.Geometry{
+		&geojson.Point{Coords: geojson.Coord{100.0, 0.0}},
+		&geojson.MultiPoint{Coords: geojson.Curve{{100.0, 0.0}, {101.0, 1.0}}},
+		&geojson.LineString{Coords: geojson.Curve{{100.0, 0.0}, {101.0, 1.0}}},
+		&geojson.MultiLineString{Coords: geojson.Surface{{{100.0, 0.0}, {101.0, 1.0}}}},
+		&geojson.Polygon{Coords: geojson.Surface{{{100.0, 0.0}, {101.0, 0.0}, {101.0, 1.0}, {100.0, 0.0}}}},
+		&geojson.MultiPolygon{Coords: geojson.Surfaces{{{{100.0, 0.0}, {101.0, 0.0}, {101.0, 1.0}, {100.0, 0.0}}}}},
+		&geojson.GeometryCollection{
+			Geometries: []geojson.Geometry{
+				&geojson.Point{Coords: geojson.Coord{100.0, 0.0}},
+				&geojson.LineString{Coords: geojson.Curve{{100.0, 0.0}, {101.0, 1.0}}},
+			},
+		},
+	} {
+		bbox := geo.BoundingBox()
+		c := geojson.Clone(geo)
+		it.Then(t).Should(
+			it.Equiv(c, geo),
+		)
+
+		c.Geometry().FMap(func(x geojson.Coord) { x[0] = -1.0 })
+		it.Then(t).Should(
+			it.Equiv(geo.BoundingBox(), bbox),
+			it.Equal(c.BoundingBox()[0], -1.0),
+		)
+	}
+}
+
+func TestFeatureClone(t *testing.T) {
+	fea := geojson.NewLineString(city_helsinki, geojson.Curve{{100.0, 0.0}, {101.0, 1.0}})
+	fea.BBox = geojson.BoundingBox{100.0, 0.0, 101.0, 1.0}
+
+	c := fea.Clone()
+	c.BBox[0] = -1.0
+	c.FMap(func(x geojson.Coord) { x[1] = -1.0 })
+
+	it.Then(t).Should(
+		it.Equal(c.ID, city_helsinki),
+		it.Equiv(fea.BBox, geojson.BoundingBox{100.0, 0.0, 101.0, 1.0}),
+		it.Equiv(fea.Geometry, geojson.Geometry(&geojson.LineString{Coords: geojson.Curve{{100.0, 0.0}, {101.0, 1.0}}})),
+		it.Equiv(c.Geometry, geojson.Geometry(&geojson.LineString{Coords: geojson.Curve{{100.0, -1.0}, {101.0, -1.0}}})),
+	)
+}
@@ -0,0 +1,235 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Triangulate decomposes the polygon into triangles, see TriangulateE for
+// details. It returns no triangles if polygon is degenerate or invalid.
+func (geo *Polygon) Triangulate() []Curve {
+	seq, _ := geo.TriangulateE()
+	return seq
+}
+
+// TriangulateE decomposes the polygon into triangles using ear clipping,
+// e.g. for rendering with WebGL. Holes are bridged into the exterior ring
+// before clipping. Each triangle is closed ring of 4 positions that
+// follows the right-hand rule, positions are copied from the polygon.
+//
+// The polygon must be valid (see IsValid), the error describes violation.
+// Triangles are computed at plane of lng, lat coordinates.
+func (geo *Polygon) TriangulateE() ([]Curve, error) {
+	if _, err := geo.IsValid(); err != nil {
+		return nil, err
+	}
+
+	rings := make([]Curve, len(geo.Coords))
+	for r, ring := range geo.Coords {
+		x, err := newValidRing(r, ring)
+		if err != nil {
+			return nil, err
+		}
+
+		// Note: exterior ring is counter-clockwise, holes are clockwise
+		seq := slices.Clone(x.coords[:len(x.coords)-1])
+		if (r == 0) == (x.coords.planarArea() < 0) {
+			seq.reverse()
+		}
+		rings[r] = seq
+	}
+
+	if geo.Coords[0].planarArea() == 0 {
+		return nil, fmt.Errorf("%w: polygon has zero area", ErrInvalidGeometry)
+	}
+
+	// Note: holes are bridged from right to left, the bridge of the hole
+	//       never crosses holes that are not merged yet.
+	holes := rings[1:]
+	slices.SortFunc(holes, func(a, b Curve) int {
+		return cmp.Compare(b[rightmost(b)].Lng(), a[rightmost(a)].Lng())
+	})
+
+	outer := rings[0]
+	for _, hole := range holes {
+		outer = bridge(outer, hole)
+	}
+
+	return earClip(outer)
+}
+
+// index of the vertex with maximum longitude
+func rightmost(seq Curve) int {
+	k := 0
+	for i, c := range seq {
+		if c.Lng() > seq[k].Lng() {
+			k = i
+		}
+	}
+	return k
+}
+
+// bridge merges the hole into the outer ring through the pair of mutually
+// visible vertices (D. Eberly, Triangulation by Ear Clipping). Both
+// vertices of the bridge are duplicated by the merged ring.
+func bridge(outer, hole Curve) Curve {
+	m := rightmost(hole)
+	mx, my := hole[m].Lng(), hole[m].Lat()
+
+	// nearest intersection of the ray from the hole towards east with outer edges
+	p, ix := -1, math.Inf(1)
+	for i, a := range outer {
+		b := outer[(i+1)%len(outer)]
+		if a.Lat() == b.Lat() || my < min(a.Lat(), b.Lat()) || my > max(a.Lat(), b.Lat()) {
+			continue
+		}
+
+		x := a.Lng() + (my-a.Lat())*(b.Lng()-a.Lng())/(b.Lat()-a.Lat())
+		if x < mx || x >= ix {
+			continue
+		}
+
+		ix = x
+		switch {
+		case a.Lat() == my:
+			p = i
+		case b.Lat() == my:
+			p = (i + 1) % len(outer)
+		case a.Lng() > b.Lng():
+			p = i
+		default:
+			p = (i + 1) % len(outer)
+		}
+	}
+
+	if p == -1 {
+		return outer
+	}
+
+	// vertices within the triangle of the ray might obstruct visibility,
+	// the one with minimal angle to the ray is visible.
+	pt, ray := outer[p], Coord{ix, my}
+	best, tan, dist := -1, math.Inf(1), math.Inf(1)
+	for i, v := range outer {
+		switch {
+		case pt.Lat() == my && (v.Lng() != pt.Lng() || v.Lat() != pt.Lat()):
+			continue
+		case pt.Lat() != my && !inTriangle(v, hole[m], ray, pt):
+			continue
+		case !locallyInside(outer, i, hole[m]):
+			continue
+		}
+
+		dx, dy := v.Lng()-mx, math.Abs(v.Lat()-my)
+		t := math.Inf(1)
+		switch {
+		case dx > 0:
+			t = dy / dx
+		case dy == 0:
+			t = 0
+		}
+
+		if d := dx*dx + dy*dy; t < tan || (t == tan && d < dist) {
+			best, tan, dist = i, t, d
+		}
+	}
+
+	if best == -1 {
+		best = p
+	}
+
+	seq := make(Curve, 0, len(outer)+len(hole)+2)
+	seq = append(seq, outer[:best+1]...)
+	seq = append(seq, hole[m:]...)
+	seq = append(seq, hole[:m+1]...)
+	seq = append(seq, outer[best:]...)
+	return seq
+}
+
+// locallyInside checks if the diagonal from the vertex i towards c is
+// within the interior angle of the counter-clockwise ring at the vertex.
+func locallyInside(seq Curve, i int, c Coord) bool {
+	n := len(seq)
+	a, v, b := seq[(i+n-1)%n], seq[i], seq[(i+1)%n]
+
+	if orientation(a, v, b) > 0 {
+		return orientation(v, c, b) <= 0 && orientation(v, a, c) <= 0
+	}
+	return orientation(v, c, a) > 0 || orientation(v, b, c) > 0
+}
+
+// inTriangle checks if the position is within the triangle, positions on
+// the boundary are within.
+func inTriangle(c, a, b, t Coord) bool {
+	d1, d2, d3 := orientation(a, b, c), orientation(b, t, c), orientation(t, a, c)
+	neg := d1 < 0 || d2 < 0 || d3 < 0
+	pos := d1 > 0 || d2 > 0 || d3 > 0
+	return !(neg && pos)
+}
+
+// earClip triangulates the counter-clockwise ring, collinear vertices are
+// removed without emitting triangles.
+func earClip(seq Curve) ([]Curve, error) {
+	n := len(seq)
+	prev, next := make([]int, n), make([]int, n)
+	for i := range seq {
+		prev[i], next[i] = (i+n-1)%n, (i+1)%n
+	}
+
+	triangles := make([]Curve, 0, n-2)
+	for i, count, stall := 0, n, 0; count > 2; {
+		if stall == count {
+			return nil, fmt.Errorf("%w: polygon cannot be triangulated", ErrInvalidGeometry)
+		}
+
+		a, b := prev[i], next[i]
+		o := orientation(seq[a], seq[i], seq[b])
+		if o < 0 || (o > 0 && !isEar(seq, next, prev, i)) {
+			i, stall = b, stall+1
+			continue
+		}
+
+		if o > 0 {
+			triangles = append(triangles,
+				Curve{clone(seq[a]), clone(seq[i]), clone(seq[b]), clone(seq[a])},
+			)
+		}
+
+		next[a], prev[b] = b, a
+		i, count, stall = b, count-1, 0
+	}
+
+	if len(triangles) == 0 {
+		return nil, fmt.Errorf("%w: polygon has zero area", ErrInvalidGeometry)
+	}
+
+	return triangles, nil
+}
+
+// isEar checks that convex vertex i forms the ear, none of reflex vertices
+// is within its triangle.
+func isEar(seq Curve, next, prev []int, i int) bool {
+	a, v, b := seq[prev[i]], seq[i], seq[next[i]]
+
+	for k := next[next[i]]; k != prev[i]; k = next[k] {
+		c := seq[k]
+		if (Curve{a, v, b}).has(c) {
+			continue
+		}
+
+		if orientation(seq[prev[k]], c, seq[next[k]]) <= 0 && inTriangle(c, a, v, b) {
+			return false
+		}
+	}
+	return true
+}
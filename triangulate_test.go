@@ -0,0 +1,142 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+// planar area of the triangle, it is positive for counter-clockwise one
+func triangleArea(t geojson.Curve) float64 {
+	a, b, c := t[0], t[1], t[2]
+	return ((b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])) / 2
+}
+
+// checks that triangles cover the polygon of the given area
+func triangulated(t *testing.T, geo *geojson.Polygon, area float64) []geojson.Curve {
+	t.Helper()
+
+	seq, err := geo.TriangulateE()
+	it.Then(t).Should(it.Nil(err))
+
+	total := 0.0
+	for _, tri := range seq {
+		it.Then(t).Should(
+			it.Equal(len(tri), 4),
+			it.Equiv(tri[0], tri[3]),
+			it.True(triangleArea(tri) > 0),
+			it.True(geo.Contains(geojson.Coord{
+				(tri[0][0] + tri[1][0] + tri[2][0]) / 3,
+				(tri[0][1] + tri[1][1] + tri[2][1]) / 3,
+			})),
+		)
+		total += triangleArea(tri)
+	}
+
+	it.Then(t).Should(
+		it.True(near(total, area, 1e-9)),
+	)
+	return seq
+}
+
+func TestTriangulate(t *testing.T) {
+	t.Run("Square", func(t *testing.T) {
+		geo := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0}, {10.0, 0.0}, {10.0, 10.0}, {0.0, 10.0}, {0.0, 0.0}},
+		}}
+		seq := triangulated(t, geo, 100.0)
+		it.Then(t).Should(it.Equal(len(seq), 2))
+	})
+
+	t.Run("Clockwise", func(t *testing.T) {
+		geo := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0}, {0.0, 10.0}, {10.0, 10.0}, {10.0, 0.0}, {0.0, 0.0}},
+		}}
+		triangulated(t, geo, 100.0)
+		it.Then(t).Should(it.True(geo.IsClockwise(0)))
+	})
+
+	t.Run("Concave", func(t *testing.T) {
+		geo := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0}, {10.0, 0.0}, {10.0, 4.0}, {4.0, 4.0}, {4.0, 10.0}, {0.0, 10.0}, {0.0, 0.0}},
+		}}
+		seq := triangulated(t, geo, 64.0)
+		it.Then(t).Should(it.Equal(len(seq), 4))
+	})
+
+	t.Run("Collinear", func(t *testing.T) {
+		geo := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0}, {5.0, 0.0}, {10.0, 0.0}, {10.0, 10.0}, {10.0, 10.0}, {0.0, 10.0}, {0.0, 0.0}},
+		}}
+		triangulated(t, geo, 100.0)
+	})
+
+	t.Run("Hole", func(t *testing.T) {
+		geo := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0}, {10.0, 0.0}, {10.0, 10.0}, {0.0, 10.0}, {0.0, 0.0}},
+			{{2.0, 2.0}, {2.0, 8.0}, {8.0, 8.0}, {8.0, 2.0}, {2.0, 2.0}},
+		}}
+		seq := triangulated(t, geo, 64.0)
+		it.Then(t).Should(it.Equal(len(seq), 8))
+	})
+
+	t.Run("Holes", func(t *testing.T) {
+		geo := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0}, {20.0, 0.0}, {20.0, 10.0}, {0.0, 10.0}, {0.0, 0.0}},
+			{{2.0, 2.0}, {8.0, 2.0}, {8.0, 8.0}, {2.0, 8.0}, {2.0, 2.0}},
+			{{12.0, 2.0}, {12.0, 8.0}, {18.0, 8.0}, {18.0, 2.0}, {12.0, 2.0}},
+		}}
+		triangulated(t, geo, 200.0-36.0-36.0)
+	})
+
+	t.Run("HoleTouchesExterior", func(t *testing.T) {
+		geo := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0}, {10.0, 0.0}, {10.0, 10.0}, {0.0, 10.0}, {0.0, 0.0}},
+			{{10.0, 5.0}, {5.0, 2.0}, {5.0, 8.0}, {10.0, 5.0}},
+		}}
+		triangulated(t, geo, 100.0-15.0)
+	})
+
+	t.Run("Elevation", func(t *testing.T) {
+		geo := &geojson.Polygon{Coords: geojson.Surface{
+			{{0.0, 0.0, 1.0}, {10.0, 0.0, 2.0}, {10.0, 10.0, 3.0}, {0.0, 0.0, 1.0}},
+		}}
+		seq := geo.Triangulate()
+		it.Then(t).Should(
+			it.Equal(len(seq), 1),
+			it.Equiv(seq[0], geojson.Curve{{10.0, 10.0, 3.0}, {0.0, 0.0, 1.0}, {10.0, 0.0, 2.0}, {10.0, 10.0, 3.0}}),
+		)
+
+		seq[0][1][2] = 100.0
+		it.Then(t).Should(it.Equal(geo.Coords[0][0][2], 1.0))
+	})
+}
+
+func TestTriangulateInvalid(t *testing.T) {
+	for name, geo := range map[string]*geojson.Polygon{
+		"Empty":   {},
+		"Open":    {Coords: geojson.Surface{{{0.0, 0.0}, {10.0, 0.0}, {10.0, 10.0}, {0.0, 10.0}}}},
+		"Bowtie":  {Coords: geojson.Surface{{{0.0, 0.0}, {10.0, 10.0}, {10.0, 0.0}, {0.0, 10.0}, {0.0, 0.0}}}},
+		"Flat":    {Coords: geojson.Surface{{{0.0, 0.0}, {5.0, 0.0}, {10.0, 0.0}, {5.0, 0.0}, {0.0, 0.0}}}},
+		"OutHole": {Coords: geojson.Surface{{{0.0, 0.0}, {1.0, 0.0}, {1.0, 1.0}, {0.0, 0.0}}, {{5.0, 5.0}, {5.0, 6.0}, {6.0, 6.0}, {5.0, 5.0}}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			seq, err := geo.TriangulateE()
+			it.Then(t).Should(
+				it.True(errors.Is(err, geojson.ErrInvalidRing) || errors.Is(err, geojson.ErrInvalidGeometry)),
+				it.True(seq == nil),
+				it.Equal(len(geo.Triangulate()), 0),
+			)
+		})
+	}
+}
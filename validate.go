@@ -0,0 +1,232 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// validateWGS84 finds first position out of geographic coordinates range
+func validateWGS84(shape Shape) error {
+	return shape.FMapErr(func(c Coord) error {
+		if !c.ValidWGS84() {
+			return fmt.Errorf("%w: %v", ErrOutOfRange, []float64(c))
+		}
+		return nil
+	})
+}
+
+// validateFinite finds first position with NaN or Inf element, JSON has
+// no representation for them.
+func validateFinite(geo Geometry) error {
+	// Note: raw geometry is valid JSON, it has no NaN or Inf
+	if _, ok := geo.(RawGeometry); ok {
+		return nil
+	}
+
+	for c := range Coordinates(geo) {
+		if !isFinite(c) {
+			return fmt.Errorf("%w: %s position %v", ErrNonFiniteCoordinate, geo.Type(), []float64(c))
+		}
+	}
+	return nil
+}
+
+// validateFiniteBBox checks that bounding box has no NaN or Inf element
+func validateFiniteBBox(bbox BoundingBox) error {
+	if !isFinite(bbox) {
+		return fmt.Errorf("%w: bbox %v", ErrNonFiniteCoordinate, []float64(bbox))
+	}
+	return nil
+}
+
+func isFinite(seq []float64) bool {
+	for _, x := range seq {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks that position is geographic coordinate (WGS84).
+// Validation is opt-in, it is not enforced during decode to support
+// non-geographic coordinate reference systems.
+func (geo *Point) Validate() error { return validateWGS84(geo.Coords) }
+
+// Validate checks that all positions are geographic coordinates (WGS84).
+func (geo *MultiPoint) Validate() error { return validateWGS84(geo.Coords) }
+
+// Validate checks that all positions are geographic coordinates (WGS84).
+func (geo *LineString) Validate() error { return validateWGS84(geo.Coords) }
+
+// Validate checks that all positions are geographic coordinates (WGS84).
+func (geo *MultiLineString) Validate() error { return validateWGS84(geo.Coords) }
+
+// Validate checks that all positions are geographic coordinates (WGS84).
+func (geo *Polygon) Validate() error { return validateWGS84(geo.Coords) }
+
+// Validate checks that all positions are geographic coordinates (WGS84).
+func (geo *MultiPolygon) Validate() error { return validateWGS84(geo.Coords) }
+
+// Validate checks that all positions are geographic coordinates (WGS84).
+func (geo *GeometryCollection) Validate() error { return validateWGS84(geo.Geometry()) }
+
+// Validate checks the feature against the structure defined by RFC 7946,
+// all violations are reported at once, joined by errors.Join:
+//   - geometry is either valid or nil, for unlocated feature;
+//   - positions have 2 to 4 finite elements (ErrNonFiniteCoordinate);
+//   - line strings have at least 2 positions;
+//   - linear rings are closed and have at least 4 positions;
+//   - multi-part geometries have at least one part;
+//   - bounding box has 2*n finite elements, n is 2 or 3.
+//
+// Positions are not checked against WGS84 range, see Validate of geometry.
+func (fea Feature) Validate() error {
+	errs := validateBBox(fea.BBox, nil)
+	errs = validateGeometry("geometry", fea.Geometry, errs)
+	return errors.Join(errs...)
+}
+
+// Validate checks each feature of the collection, see Feature.Validate.
+// Violations are prefixed with index of the feature, e.g. "feature 2: ...".
+// Only features that embed Feature are inspected.
+func (c Collection[T]) Validate() error {
+	var errs []error
+	for i := range c.Features {
+		f, ok := any(&c.Features[i]).(interface{ feature() *Feature })
+		if !ok {
+			continue
+		}
+
+		if err, ok := f.feature().Validate().(interface{ Unwrap() []error }); ok {
+			for _, e := range err.Unwrap() {
+				errs = append(errs, fmt.Errorf("feature %d: %w", i, e))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateBBox(bbox BoundingBox, errs []error) []error {
+	if bbox == nil {
+		return errs
+	}
+
+	if len(bbox) != 4 && len(bbox) != 6 {
+		return append(errs, fmt.Errorf("%w: bbox has %d elements", ErrInvalidPosition, len(bbox)))
+	}
+
+	if err := validateFiniteBBox(bbox); err != nil {
+		return append(errs, err)
+	}
+	return errs
+}
+
+func validateGeometry(path string, geo Geometry, errs []error) []error {
+	switch v := geo.(type) {
+	case nil:
+		return errs
+	case *Point:
+		if len(v.Coords) == 0 {
+			return append(errs, fmt.Errorf("%w: %s is point without position", ErrEmptyCoordinates, path))
+		}
+		return validatePosition(path+".coordinates", v.Coords, errs)
+	case *MultiPoint:
+		if len(v.Coords) == 0 {
+			return append(errs, fmt.Errorf("%w: %s is multipoint without positions", ErrEmptyCoordinates, path))
+		}
+		for i, c := range v.Coords {
+			errs = validatePosition(fmt.Sprintf("%s.coordinates[%d]", path, i), c, errs)
+		}
+		return errs
+	case *LineString:
+		return validateLine(path+".coordinates", v.Coords, errs)
+	case *MultiLineString:
+		if len(v.Coords) == 0 {
+			return append(errs, fmt.Errorf("%w: %s is multilinestring without lines", ErrEmptyCoordinates, path))
+		}
+		for i, line := range v.Coords {
+			errs = validateLine(fmt.Sprintf("%s.coordinates[%d]", path, i), line, errs)
+		}
+		return errs
+	case *Polygon:
+		return validateRings(path+".coordinates", v.Coords, errs)
+	case *MultiPolygon:
+		if len(v.Coords) == 0 {
+			return append(errs, fmt.Errorf("%w: %s is multipolygon without polygons", ErrEmptyCoordinates, path))
+		}
+		for i, surface := range v.Coords {
+			errs = validateRings(fmt.Sprintf("%s.coordinates[%d]", path, i), surface, errs)
+		}
+		return errs
+	case *GeometryCollection:
+		for i, x := range v.Geometries {
+			member := fmt.Sprintf("%s.geometries[%d]", path, i)
+			if x == nil {
+				errs = append(errs, fmt.Errorf("%w: %s is null", ErrInvalidGeometry, member))
+				continue
+			}
+			errs = validateGeometry(member, x, errs)
+		}
+		return errs
+	default:
+		return append(errs, fmt.Errorf("%w: %s is %T", ErrUnsupportedType, path, geo))
+	}
+}
+
+func validatePosition(path string, c Coord, errs []error) []error {
+	if len(c) < 2 || len(c) > 4 {
+		return append(errs, fmt.Errorf("%w: %s position %v has %d elements", ErrInvalidPosition, path, []float64(c), len(c)))
+	}
+
+	if !isFinite(c) {
+		return append(errs, fmt.Errorf("%w: %s position %v", ErrNonFiniteCoordinate, path, []float64(c)))
+	}
+	return errs
+}
+
+func validateLine(path string, seq Curve, errs []error) []error {
+	if len(seq) < 2 {
+		errs = append(errs, fmt.Errorf("%w: %s line has %d positions, at least 2 required", ErrInvalidGeometry, path, len(seq)))
+	}
+
+	for i, c := range seq {
+		errs = validatePosition(fmt.Sprintf("%s[%d]", path, i), c, errs)
+	}
+	return errs
+}
+
+func validateRings(path string, seq Surface, errs []error) []error {
+	if len(seq) == 0 {
+		return append(errs, fmt.Errorf("%w: %s polygon has no rings", ErrEmptyCoordinates, path))
+	}
+
+	for r, ring := range seq {
+		ringPath := fmt.Sprintf("%s[%d]", path, r)
+		if len(ring) < 4 {
+			errs = append(errs, fmt.Errorf("%w: %s ring has %d positions, at least 4 required", ErrInvalidRing, ringPath, len(ring)))
+		}
+
+		n := len(errs)
+		for i, c := range ring {
+			errs = validatePosition(fmt.Sprintf("%s[%d]", ringPath, i), c, errs)
+		}
+
+		if n == len(errs) && len(ring) > 0 {
+			if a, b := ring[0], ring[len(ring)-1]; a.Lng() != b.Lng() || a.Lat() != b.Lat() {
+				errs = append(errs, fmt.Errorf("%w: %s ring is not closed", ErrInvalidRing, ringPath))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,121 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestValidWGS84(t *testing.T) {
+	it.Then(t).Should(
+		it.True(geojson.Coord{180.0, 90.0}.ValidWGS84()),
+		it.True(geojson.Coord{-180.0, -90.0, 1000.0}.ValidWGS84()),
+	).ShouldNot(
+		it.True(geojson.Coord{60.0, 181.0}.ValidWGS84()),
+		it.True(geojson.Coord{181.0, 60.0}.ValidWGS84()),
+		it.True(geojson.Coord{0.0, -90.1}.ValidWGS84()),
+		it.True(geojson.Coord{0.0}.ValidWGS84()),
+	)
+}
+
+func TestValidateWGS84(t *testing.T) {
+	it.Then(t).Should(
+		it.Nil((&geojson.Point{Coords: coordPoint}).Validate()),
+		it.Nil((&geojson.MultiPoint{Coords: coordMultiPoint}).Validate()),
+		it.Nil((&geojson.LineString{Coords: coordLineString}).Validate()),
+		it.Nil((&geojson.MultiLineString{Coords: coordMultiLineString}).Validate()),
+		it.Nil((&geojson.Polygon{Coords: coordPolygonWithHole}).Validate()),
+		it.Nil((&geojson.MultiPolygon{Coords: coordMultiPolygon}).Validate()),
+		it.Nil((&geojson.GeometryCollection{}).Validate()),
+
+		it.Fail((&geojson.LineString{
+			Coords: geojson.Curve{{100.0, 0.0}, {200.0, 1.0}, {300.0, 1.0}},
+		}).Validate).Contain("[200 1]"),
+		it.Fail((&geojson.GeometryCollection{
+			Geometries: []geojson.Geometry{
+				&geojson.Point{Coords: coordPoint},
+				&geojson.Point{Coords: geojson.Coord{24.9384, 360.0}},
+			},
+		}).Validate).Contain("out of WGS84 range"),
+	)
+}
+
+func TestFeatureValidate(t *testing.T) {
+	ring := geojson.Curve{{0, 0}, {1, 0}, {1, 1}, {0, 0}}
+
+	for _, fea := range []geojson.Feature{
+		{ID: "a:unlocated"},
+		geojson.NewPoint("a:point", geojson.Coord{24.9, 60.1, 10.0}),
+		geojson.NewLineString("a:line", geojson.Curve{{0, 0}, {1, 1}}),
+		geojson.NewPolygon("a:polygon", geojson.Surface{ring}),
+		geojson.NewMultiPolygon("a:multipolygon", geojson.Surface{ring}),
+		{Geometry: &geojson.GeometryCollection{}},
+	} {
+		it.Then(t).Should(
+			it.Nil(fea.Validate()),
+		)
+	}
+
+	fea := geojson.Feature{
+		BBox: geojson.BoundingBox{0, 0, 1},
+		Geometry: &geojson.GeometryCollection{
+			Geometries: []geojson.Geometry{
+				&geojson.Point{},
+				&geojson.LineString{Coords: geojson.Curve{{0, math.NaN()}}},
+				&geojson.Polygon{Coords: geojson.Surface{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}},
+				&geojson.MultiPoint{},
+				nil,
+			},
+		},
+	}
+
+	err := fea.Validate()
+	msg := strings.Split(err.Error(), "\n")
+	it.Then(t).Should(
+		it.Equal(len(msg), 7),
+		it.True(errors.Is(err, geojson.ErrInvalidPosition)),
+		it.True(errors.Is(err, geojson.ErrEmptyCoordinates)),
+		it.True(errors.Is(err, geojson.ErrInvalidGeometry)),
+		it.True(errors.Is(err, geojson.ErrInvalidRing)),
+		it.True(errors.Is(err, geojson.ErrNonFiniteCoordinate)),
+		it.String(msg[0]).Contain("bbox has 3 elements"),
+		it.String(msg[1]).Contain("geometry.geometries[0] is point without position"),
+		it.String(msg[2]).Contain("geometry.geometries[1].coordinates line has 1 positions"),
+		it.String(msg[3]).Contain("geometry.geometries[1].coordinates[0] position [0 NaN]"),
+		it.String(msg[4]).Contain("geometry.geometries[2].coordinates[0] ring is not closed"),
+		it.String(msg[5]).Contain("geometry.geometries[3] is multipoint without positions"),
+		it.String(msg[6]).Contain("geometry.geometries[4] is null"),
+	)
+}
+
+func TestCollectionValidate(t *testing.T) {
+	c := geojson.Collection[geojson.Feature]{
+		Features: []geojson.Feature{
+			geojson.NewPoint("a:valid", geojson.Coord{1, 1}),
+			geojson.NewLineString("a:line", geojson.Curve{{1, 1}}),
+			geojson.NewPoint("a:point", geojson.Coord{math.Inf(1), 1}),
+		},
+	}
+
+	err := c.Validate()
+	msg := strings.Split(err.Error(), "\n")
+	it.Then(t).Should(
+		it.Equal(len(msg), 2),
+		it.String(msg[0]).Contain("feature 1: "),
+		it.String(msg[1]).Contain("feature 2: "),
+		it.True(errors.Is(err, geojson.ErrInvalidGeometry)),
+		it.Nil(geojson.Collection[geojson.Feature]{Features: c.Features[:1]}.Validate()),
+	)
+}
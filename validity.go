@@ -0,0 +1,170 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import "fmt"
+
+// IsValid checks polygon against OGC simple feature rules, it returns the
+// error describing the first violation:
+//   - ring is closed and has at least four positions;
+//   - ring does not self-intersect;
+//   - holes are inside the exterior ring and not nested into each other;
+//   - rings touch each other at most at a single point.
+//
+// Repeated consecutive positions are allowed. The connectivity of
+// polygon's interior is not checked. Positions are compared at plane
+// of lng, lat coordinates.
+func (geo *Polygon) IsValid() (bool, error) {
+	if len(geo.Coords) == 0 {
+		return false, fmt.Errorf("%w: polygon has no rings: %w", ErrInvalidRing, ErrEmptyCoordinates)
+	}
+
+	rings := make([]validRing, len(geo.Coords))
+	for r, ring := range geo.Coords {
+		x, err := newValidRing(r, ring)
+		if err != nil {
+			return false, err
+		}
+		if err := x.simple(); err != nil {
+			return false, err
+		}
+		rings[r] = x
+	}
+
+	for r := 1; r < len(rings); r++ {
+		if err := rings[r].within(rings[0]); err != nil {
+			return false, err
+		}
+
+		for k := 0; k < r; k++ {
+			if err := rings[r].touch(rings[k]); err != nil {
+				return false, err
+			}
+
+			if k > 0 && (rings[r].nested(rings[k]) || rings[k].nested(rings[r])) {
+				return false, fmt.Errorf("%w: hole %d is nested with hole %d", ErrInvalidRing, r, k)
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// validRing is a ring without repeated consecutive positions, index keeps
+// original position of each vertex.
+type validRing struct {
+	id     int
+	coords Curve
+	index  []int
+}
+
+func newValidRing(id int, ring Curve) (validRing, error) {
+	if len(ring) < 4 {
+		return validRing{}, fmt.Errorf("%w: ring %d has %d positions, at least 4 required", ErrInvalidRing, id, len(ring))
+	}
+
+	for _, c := range ring {
+		if len(c) < 2 {
+			return validRing{}, fmt.Errorf("%w: ring %d has position %v", ErrInvalidRing, id, c)
+		}
+	}
+
+	if a, b := ring[0], ring[len(ring)-1]; a.Lng() != b.Lng() || a.Lat() != b.Lat() {
+		return validRing{}, fmt.Errorf("%w: ring %d is not closed", ErrInvalidRing, id)
+	}
+
+	x := validRing{id: id}
+	for i, c := range ring {
+		if n := len(x.coords); n > 0 && x.coords[n-1].Lng() == c.Lng() && x.coords[n-1].Lat() == c.Lat() {
+			continue
+		}
+		x.coords = append(x.coords, c)
+		x.index = append(x.index, i)
+	}
+
+	if len(x.coords) < 4 {
+		return validRing{}, fmt.Errorf("%w: ring %d has %d distinct positions, at least 4 required", ErrInvalidRing, id, len(x.coords))
+	}
+
+	return x, nil
+}
+
+// simple checks that ring does not self-intersect. Adjacent segments
+// share only the common vertex, they must not fold back onto each other.
+func (x validRing) simple() error {
+	n := len(x.coords) - 1
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			var fold bool
+			switch {
+			case j == i+1:
+				fold = folds(x.coords[i], x.coords[j], x.coords[j+1])
+			case i == 0 && j == n-1:
+				fold = folds(x.coords[j], x.coords[0], x.coords[1])
+			default:
+				fold = len(intersectSegments(x.coords[i], x.coords[i+1], x.coords[j], x.coords[j+1])) > 0
+			}
+
+			if fold {
+				return fmt.Errorf("%w: ring %d self-intersects at segments %d and %d", ErrInvalidRing, x.id, x.index[i], x.index[j])
+			}
+		}
+	}
+	return nil
+}
+
+// folds checks if adjacent segments a, b and b, c overlap
+func folds(a, b, c Coord) bool {
+	if orientation(a, b, c) != 0 {
+		return false
+	}
+	return (a.Lng()-b.Lng())*(c.Lng()-b.Lng())+(a.Lat()-b.Lat())*(c.Lat()-b.Lat()) > 0
+}
+
+// within checks that the hole is inside the exterior ring
+func (x validRing) within(exterior validRing) error {
+	for i, c := range x.coords {
+		if exterior.coords.ring(c) < 0 {
+			return fmt.Errorf("%w: hole %d is outside of exterior at position %d", ErrInvalidRing, x.id, x.index[i])
+		}
+	}
+	return nil
+}
+
+// nested checks if the ring is inside the other one
+func (x validRing) nested(other validRing) bool {
+	for _, c := range x.coords {
+		switch other.coords.ring(c) {
+		case +1:
+			return true
+		case -1:
+			return false
+		}
+	}
+	return false
+}
+
+// touch checks that rings have at most one common position
+func (x validRing) touch(other validRing) error {
+	seq := Curve{}
+	for i := 0; i+1 < len(x.coords); i++ {
+		for k := 0; k+1 < len(other.coords); k++ {
+			for _, c := range intersectSegments(x.coords[i], x.coords[i+1], other.coords[k], other.coords[k+1]) {
+				if !seq.has(c) {
+					seq = append(seq, c)
+				}
+			}
+
+			if len(seq) > 1 {
+				return fmt.Errorf("%w: ring %d intersects ring %d at segment %d", ErrInvalidRing, x.id, other.id, x.index[i])
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestPolygonIsValid(t *testing.T) {
+	square := geojson.Curve{{0.0, 0.0}, {10.0, 0.0}, {10.0, 10.0}, {0.0, 10.0}, {0.0, 0.0}}
+
+	for _, surface := range []geojson.Surface{
+		coordPolygon,
+		coordPolygonWithHole,
+		{square},
+		{{{0.0, 0.0}, {10.0, 0.0}, {10.0, 0.0}, {10.0, 10.0}, {0.0, 10.0}, {0.0, 0.0}}},
+		// holes touching at single point
+		{
+			square,
+			{{1.0, 1.0}, {1.0, 4.0}, {4.0, 4.0}, {4.0, 1.0}, {1.0, 1.0}},
+			{{4.0, 4.0}, {4.0, 8.0}, {8.0, 8.0}, {8.0, 4.0}, {4.0, 4.0}},
+		},
+		// hole touching exterior at single point
+		{
+			square,
+			{{0.0, 5.0}, {5.0, 8.0}, {5.0, 2.0}, {0.0, 5.0}},
+		},
+	} {
+		ok, err := (&geojson.Polygon{Coords: surface}).IsValid()
+		it.Then(t).Should(
+			it.Nil(err),
+			it.True(ok),
+		)
+	}
+}
+
+func TestPolygonIsInvalid(t *testing.T) {
+	square := geojson.Curve{{0.0, 0.0}, {10.0, 0.0}, {10.0, 10.0}, {0.0, 10.0}, {0.0, 0.0}}
+
+	for expect, surface := range map[string]geojson.Surface{
+		"polygon has no rings":                       {},
+		"ring 0 has 3 positions":                     {{{0.0, 0.0}, {1.0, 0.0}, {0.0, 0.0}}},
+		"ring 0 is not closed":                       {{{0.0, 0.0}, {1.0, 0.0}, {1.0, 1.0}, {0.0, 1.0}}},
+		"ring 0 has 3 distinct positions":            {{{0.0, 0.0}, {1.0, 0.0}, {1.0, 0.0}, {0.0, 0.0}}},
+		"ring 0 self-intersects at":                  {{{0.0, 0.0}, {10.0, 10.0}, {10.0, 0.0}, {0.0, 10.0}, {0.0, 0.0}}},
+		"ring 0 self-intersects at segments 0 and 2": {{{0.0, 0.0}, {10.0, 0.0}, {15.0, 0.0}, {10.0, 0.0}, {10.0, 10.0}, {0.0, 0.0}}},
+		"hole 1 is outside of exterior":              {square, {{20.0, 20.0}, {21.0, 20.0}, {21.0, 21.0}, {20.0, 20.0}}},
+		"ring 1 intersects ring 0":                   {square, {{0.0, 0.0}, {5.0, 5.0}, {5.0, 0.0}, {0.0, 0.0}}},
+		"ring 2 intersects ring 1": {
+			square,
+			{{1.0, 1.0}, {1.0, 4.0}, {4.0, 4.0}, {4.0, 1.0}, {1.0, 1.0}},
+			{{4.0, 1.0}, {4.0, 4.0}, {8.0, 4.0}, {8.0, 1.0}, {4.0, 1.0}},
+		},
+		"hole 2 is nested with hole 1": {
+			square,
+			{{1.0, 1.0}, {1.0, 8.0}, {8.0, 8.0}, {8.0, 1.0}, {1.0, 1.0}},
+			{{2.0, 2.0}, {2.0, 4.0}, {4.0, 4.0}, {4.0, 2.0}, {2.0, 2.0}},
+		},
+	} {
+		ok, err := (&geojson.Polygon{Coords: surface}).IsValid()
+		it.Then(t).Should(
+			it.Equal(ok, false),
+			it.True(errors.Is(err, geojson.ErrInvalidRing)),
+			it.Fail(func() error { return err }).Contain(expect),
+		)
+	}
+}
+
+func TestPolygonIsValidEmpty(t *testing.T) {
+	_, err := (&geojson.Polygon{}).IsValid()
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrInvalidRing)),
+		it.True(errors.Is(err, geojson.ErrEmptyCoordinates)),
+	)
+}
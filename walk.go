@@ -0,0 +1,110 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import "iter"
+
+// Visitor of geometry types, see Walk. Embed BaseVisitor to implement
+// only methods of interest.
+type Visitor interface {
+	OnPoint(*Point)
+	OnMultiPoint(*MultiPoint)
+	OnLineString(*LineString)
+	OnMultiLineString(*MultiLineString)
+	OnPolygon(*Polygon)
+	OnMultiPolygon(*MultiPolygon)
+	OnGeometryCollection(*GeometryCollection)
+}
+
+// BaseVisitor is no-op Visitor, it is embedded by application visitors
+//
+//	type counter struct {
+//		geojson.BaseVisitor
+//		polygons int
+//	}
+//
+//	func (c *counter) OnPolygon(*geojson.Polygon) { c.polygons++ }
+type BaseVisitor struct{}
+
+func (BaseVisitor) OnPoint(*Point)                           {}
+func (BaseVisitor) OnMultiPoint(*MultiPoint)                 {}
+func (BaseVisitor) OnLineString(*LineString)                 {}
+func (BaseVisitor) OnMultiLineString(*MultiLineString)       {}
+func (BaseVisitor) OnPolygon(*Polygon)                       {}
+func (BaseVisitor) OnMultiPolygon(*MultiPolygon)             {}
+func (BaseVisitor) OnGeometryCollection(*GeometryCollection) {}
+
+// Walk dispatches geometry to the visitor method of its type. The visitor
+// sees GeometryCollection before its members, members are walked in order.
+// It does nothing for undefined geometry. Unlike FMap, which sees only
+// positions, the visitor is aware of geometry structure.
+func Walk(geo Geometry, v Visitor) {
+	switch g := geo.(type) {
+	case *Point:
+		v.OnPoint(g)
+	case *MultiPoint:
+		v.OnMultiPoint(g)
+	case *LineString:
+		v.OnLineString(g)
+	case *MultiLineString:
+		v.OnMultiLineString(g)
+	case *Polygon:
+		v.OnPolygon(g)
+	case *MultiPolygon:
+		v.OnMultiPolygon(g)
+	case *GeometryCollection:
+		v.OnGeometryCollection(g)
+		for _, x := range g.Geometries {
+			Walk(x, v)
+		}
+	}
+}
+
+// Coordinates returns lazy sequence of positions of the geometry, members of
+// GeometryCollection are visited in order. The positions are shared with
+// the geometry, they are not copied. The iteration is not materialized,
+// it stops as soon as the loop breaks.
+//
+//	for c := range geojson.Coordinates(geo) {
+//	  ...
+//	}
+func Coordinates(geo Geometry) iter.Seq[Coord] {
+	return func(yield func(Coord) bool) {
+		coordinates(geo, yield)
+	}
+}
+
+// sentinel to break FMapErr iteration
+const errStopIteration = Error("stop iteration")
+
+func coordinates(geo Geometry, yield func(Coord) bool) bool {
+	switch v := geo.(type) {
+	case nil:
+		return true
+	case *GeometryCollection:
+		for _, x := range v.Geometries {
+			if !coordinates(x, yield) {
+				return false
+			}
+		}
+		return true
+	}
+
+	err := geo.Geometry().FMapErr(func(c Coord) error {
+		// Note: empty Point has no positions
+		if len(c) == 0 {
+			return nil
+		}
+		if !yield(c) {
+			return errStopIteration
+		}
+		return nil
+	})
+	return err == nil
+}
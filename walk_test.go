@@ -0,0 +1,103 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+type typeVisitor struct {
+	geojson.BaseVisitor
+	seq []string
+}
+
+func (v *typeVisitor) OnPoint(*geojson.Point)           { v.seq = append(v.seq, "Point") }
+func (v *typeVisitor) OnLineString(*geojson.LineString) { v.seq = append(v.seq, "LineString") }
+func (v *typeVisitor) OnPolygon(*geojson.Polygon)       { v.seq = append(v.seq, "Polygon") }
+func (v *typeVisitor) OnGeometryCollection(*geojson.GeometryCollection) {
+	v.seq = append(v.seq, "GeometryCollection")
+}
+
+func TestWalk(t *testing.T) {
+	geo := &geojson.GeometryCollection{
+		Geometries: []geojson.Geometry{
+			&geojson.Point{Coords: coordPoint},
+			&geojson.MultiPoint{Coords: coordMultiPoint},
+			&geojson.GeometryCollection{
+				Geometries: []geojson.Geometry{
+					&geojson.LineString{Coords: coordLineString},
+					&geojson.MultiLineString{Coords: coordMultiLineString},
+				},
+			},
+			&geojson.Polygon{Coords: coordPolygon},
+			&geojson.MultiPolygon{Coords: coordMultiPolygon},
+		},
+	}
+
+	v := &typeVisitor{}
+	geojson.Walk(geo, v)
+	geojson.Walk(nil, v)
+
+	it.Then(t).Should(
+		it.Seq(v.seq).Equal(
+			"GeometryCollection", "Point", "GeometryCollection", "LineString", "Polygon",
+		),
+	)
+}
+
+func TestWalkBaseVisitor(t *testing.T) {
+	for _, geo := range []geojson.Geometry{
+		&geojson.Point{Coords: coordPoint},
+		&geojson.MultiPoint{Coords: coordMultiPoint},
+		&geojson.LineString{Coords: coordLineString},
+		&geojson.MultiLineString{Coords: coordMultiLineString},
+		&geojson.Polygon{Coords: coordPolygon},
+		&geojson.MultiPolygon{Coords: coordMultiPolygon},
+		&geojson.GeometryCollection{},
+	} {
+		geojson.Walk(geo, geojson.BaseVisitor{})
+	}
+}
+
+func TestCoordinates(t *testing.T) {
+	geo := &geojson.GeometryCollection{
+		Geometries: []geojson.Geometry{
+			&geojson.Point{Coords: geojson.Coord{1.0, 1.0}},
+			nil,
+			&geojson.Point{},
+			&geojson.LineString{Coords: geojson.Curve{{2.0, 2.0}, {3.0, 3.0}}},
+			&geojson.MultiPolygon{Coords: geojson.Surfaces{{{{4.0, 4.0}, {5.0, 5.0}}}}},
+		},
+	}
+
+	seq := []geojson.Coord{}
+	for c := range geojson.Coordinates(geo) {
+		seq = append(seq, c)
+	}
+
+	head := []geojson.Coord{}
+	for c := range geojson.Coordinates(geo) {
+		if c.Lng() > 2.0 {
+			break
+		}
+		head = append(head, c)
+	}
+
+	it.Then(t).Should(
+		it.Equiv(seq, []geojson.Coord{{1.0, 1.0}, {2.0, 2.0}, {3.0, 3.0}, {4.0, 4.0}, {5.0, 5.0}}),
+		it.Equiv(head, []geojson.Coord{{1.0, 1.0}, {2.0, 2.0}}),
+	)
+
+	for range geojson.Coordinates(nil) {
+		t.Error("no positions are expected")
+	}
+}
@@ -0,0 +1,432 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// OGC WKB geometry type codes
+const (
+	wkbPoint              = 1
+	wkbLineString         = 2
+	wkbPolygon            = 3
+	wkbMultiPoint         = 4
+	wkbMultiLineString    = 5
+	wkbMultiPolygon       = 6
+	wkbGeometryCollection = 7
+
+	wkbZ  = 1000
+	wkbM  = 2000
+	wkbZM = 3000
+)
+
+// MarshalWKB encodes geometry to OGC Well-Known Binary (WKB) format using
+// given byte order, e.g. binary.LittleEndian or binary.NativeEndian.
+// Geometry of 3D positions is encoded as WKB Z variant (type codes +1000),
+// positions with measure as ZM variant (+3000). Positions of each geometry
+// must have the same dimension. Empty point is encoded with NaN coordinates.
+func MarshalWKB(geo Geometry, order binary.ByteOrder) ([]byte, error) {
+	w := wkbWriter{order: order, marker: wkbByteOrder(order)}
+	if err := w.geometry(geo); err != nil {
+		return nil, err
+	}
+	return w.buf, nil
+}
+
+type wkbWriter struct {
+	order  binary.ByteOrder
+	marker byte
+	buf    []byte
+	dim    int
+}
+
+// wkbByteOrder is the byte order marker of WKB, the order is probed
+// as any implementation (e.g. binary.NativeEndian) might be given.
+func wkbByteOrder(order binary.ByteOrder) byte {
+	var b [2]byte
+	order.PutUint16(b[:], 1)
+	return b[0]
+}
+
+// dimensionOf positions of the shape, all positions must have the same
+// number of elements. Empty positions (empty point) are skipped.
+func dimensionOf(t geometryType, shape Shape) (int, error) {
+	dim := 0
+	err := shape.FMapErr(func(c Coord) error {
+		switch n := len(c); {
+		case n == 0:
+			return nil
+		case n < 2 || n > 4:
+			return fmt.Errorf("%w: %s position %v has %d elements", ErrInvalidPosition, t, c, n)
+		case dim == 0:
+			dim = n
+		case dim != n:
+			return fmt.Errorf("%w: %s mixes positions of %d and %d elements", ErrInvalidPosition, t, dim, n)
+		}
+		return nil
+	})
+
+	return max(dim, 2), err
+}
+
+func (w *wkbWriter) uint32(v uint32) {
+	var b [4]byte
+	w.order.PutUint32(b[:], v)
+	w.buf = append(w.buf, b[:]...)
+}
+
+func (w *wkbWriter) float64(v float64) {
+	var b [8]byte
+	w.order.PutUint64(b[:], math.Float64bits(v))
+	w.buf = append(w.buf, b[:]...)
+}
+
+func (w *wkbWriter) header(t geometryType, code uint32, shape Shape) error {
+	dim, err := dimensionOf(t, shape)
+	if err != nil {
+		return err
+	}
+	w.dim = dim
+
+	w.buf = append(w.buf, w.marker)
+
+	switch w.dim {
+	case 3:
+		code += wkbZ
+	case 4:
+		code += wkbZM
+	}
+	w.uint32(code)
+	return nil
+}
+
+func (w *wkbWriter) coord(c Coord) {
+	for i := 0; i < w.dim; i++ {
+		w.float64(c[i])
+	}
+}
+
+func (w *wkbWriter) curve(seq Curve) {
+	w.uint32(uint32(len(seq)))
+	for _, c := range seq {
+		w.coord(c)
+	}
+}
+
+func (w *wkbWriter) surface(seq Surface) {
+	w.uint32(uint32(len(seq)))
+	for _, curve := range seq {
+		w.curve(curve)
+	}
+}
+
+func (w *wkbWriter) geometry(geo Geometry) error {
+	switch v := geo.(type) {
+	case *Point:
+		if err := w.header(typePoint, wkbPoint, v.Coords); err != nil {
+			return err
+		}
+		if len(v.Coords) == 0 {
+			w.float64(math.NaN())
+			w.float64(math.NaN())
+			return nil
+		}
+		w.coord(v.Coords)
+	case *MultiPoint:
+		if err := w.header(typeMultiPoint, wkbMultiPoint, v.Coords); err != nil {
+			return err
+		}
+		w.uint32(uint32(len(v.Coords)))
+		for _, c := range v.Coords {
+			if err := w.geometry(&Point{Coords: c}); err != nil {
+				return err
+			}
+		}
+	case *LineString:
+		if err := w.header(typeLineString, wkbLineString, v.Coords); err != nil {
+			return err
+		}
+		w.curve(v.Coords)
+	case *MultiLineString:
+		if err := w.header(typeMultiLineString, wkbMultiLineString, v.Coords); err != nil {
+			return err
+		}
+		w.uint32(uint32(len(v.Coords)))
+		for _, curve := range v.Coords {
+			if err := w.geometry(&LineString{Coords: curve}); err != nil {
+				return err
+			}
+		}
+	case *Polygon:
+		if err := w.header(typePolygon, wkbPolygon, v.Coords); err != nil {
+			return err
+		}
+		w.surface(v.Coords)
+	case *MultiPolygon:
+		if err := w.header(typeMultiPolygon, wkbMultiPolygon, v.Coords); err != nil {
+			return err
+		}
+		w.uint32(uint32(len(v.Coords)))
+		for _, surface := range v.Coords {
+			if err := w.geometry(&Polygon{Coords: surface}); err != nil {
+				return err
+			}
+		}
+	case *GeometryCollection:
+		if err := w.header(typeGeometryCollection, wkbGeometryCollection, Shapes{}); err != nil {
+			return err
+		}
+		w.uint32(uint32(len(v.Geometries)))
+		for _, x := range v.Geometries {
+			if err := w.geometry(x); err != nil {
+				return err
+			}
+		}
+	default:
+		return ErrUnsupportedType
+	}
+
+	return nil
+}
+
+// UnmarshalWKB decodes geometry from OGC Well-Known Binary (WKB) format.
+// Both little- and big-endian byte orders are supported, as well as
+// Z and ZM variants of geometry types. The M variant is rejected, the
+// measure is the fourth element of position, which requires altitude.
+// The empty point, encoded as NaN coordinates, is skipped as member of
+// MultiPoint.
+func UnmarshalWKB(b []byte) (Geometry, error) {
+	r := wkbReader{buf: b}
+	geo, err := r.geometry()
+	if err != nil {
+		return nil, err
+	}
+
+	if r.pos != len(r.buf) {
+		return nil, r.fail("unexpected trailing bytes")
+	}
+
+	return geo, nil
+}
+
+type wkbReader struct {
+	buf   []byte
+	pos   int
+	order binary.ByteOrder
+	dim   int
+}
+
+func (r *wkbReader) fail(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at %d", ErrInvalidWKB, fmt.Sprintf(format, args...), r.pos)
+}
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if r.pos+4 > len(r.buf) {
+		return 0, r.fail("unexpected end of input")
+	}
+	v := r.order.Uint32(r.buf[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+// length of sequence, it is bounded by size of the remaining input
+func (r *wkbReader) length(size int) (int, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return 0, err
+	}
+	if int(n) > (len(r.buf)-r.pos)/size {
+		return 0, r.fail("invalid length %d", n)
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) coord() (Coord, error) {
+	if r.pos+8*r.dim > len(r.buf) {
+		return nil, r.fail("unexpected end of input")
+	}
+
+	c := make(Coord, r.dim)
+	for i := range c {
+		c[i] = math.Float64frombits(r.order.Uint64(r.buf[r.pos:]))
+		r.pos += 8
+	}
+	return c, nil
+}
+
+func (r *wkbReader) curve() (Curve, error) {
+	n, err := r.length(8 * r.dim)
+	if err != nil {
+		return nil, err
+	}
+
+	seq := make(Curve, n)
+	for i := range seq {
+		if seq[i], err = r.coord(); err != nil {
+			return nil, err
+		}
+	}
+	return seq, nil
+}
+
+func (r *wkbReader) surface() (Surface, error) {
+	n, err := r.length(4)
+	if err != nil {
+		return nil, err
+	}
+
+	seq := make(Surface, n)
+	for i := range seq {
+		if seq[i], err = r.curve(); err != nil {
+			return nil, err
+		}
+	}
+	return seq, nil
+}
+
+func (r *wkbReader) header() (uint32, error) {
+	if r.pos >= len(r.buf) {
+		return 0, r.fail("unexpected end of input")
+	}
+
+	switch r.buf[r.pos] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return 0, r.fail("invalid byte order %d", r.buf[r.pos])
+	}
+	r.pos++
+
+	code, err := r.uint32()
+	if err != nil {
+		return 0, err
+	}
+
+	switch code / 1000 * 1000 {
+	case 0:
+		r.dim = 2
+	case wkbZ:
+		r.dim = 3
+	case wkbM:
+		return 0, r.fail("M variant of type %d is not supported", code)
+	case wkbZM:
+		r.dim = 4
+	default:
+		return 0, r.fail("type %d is not supported", code)
+	}
+
+	return code % 1000, nil
+}
+
+// children of multi geometry, each of the expected type
+func (r *wkbReader) children(code uint32, f func(Geometry)) error {
+	n, err := r.length(5)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		geo, err := r.geometry()
+		if err != nil {
+			return err
+		}
+
+		if code != 0 && wkbCode(geo) != code {
+			return r.fail("unexpected element of multi geometry")
+		}
+		f(geo)
+	}
+	return nil
+}
+
+// wkbCode of single part geometry
+func wkbCode(geo Geometry) uint32 {
+	switch geo.(type) {
+	case *Point:
+		return wkbPoint
+	case *LineString:
+		return wkbLineString
+	case *Polygon:
+		return wkbPolygon
+	default:
+		return 0
+	}
+}
+
+func (r *wkbReader) geometry() (Geometry, error) {
+	code, err := r.header()
+	if err != nil {
+		return nil, err
+	}
+
+	switch code {
+	case wkbPoint:
+		c, err := r.coord()
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(c[0]) && math.IsNaN(c[1]) {
+			return &Point{}, nil
+		}
+		return &Point{Coords: c}, nil
+
+	case wkbLineString:
+		seq, err := r.curve()
+		if err != nil {
+			return nil, err
+		}
+		return &LineString{Coords: seq}, nil
+
+	case wkbPolygon:
+		seq, err := r.surface()
+		if err != nil {
+			return nil, err
+		}
+		return &Polygon{Coords: seq}, nil
+
+	case wkbMultiPoint:
+		geo := &MultiPoint{Coords: Curve{}}
+		err := r.children(wkbPoint, func(x Geometry) {
+			// Note: GeoJSON has no empty position, the NaN point is skipped
+			if c := x.(*Point).Coords; len(c) != 0 {
+				geo.Coords = append(geo.Coords, c)
+			}
+		})
+		return geo, err
+
+	case wkbMultiLineString:
+		geo := &MultiLineString{Coords: Surface{}}
+		err := r.children(wkbLineString, func(x Geometry) {
+			geo.Coords = append(geo.Coords, x.(*LineString).Coords)
+		})
+		return geo, err
+
+	case wkbMultiPolygon:
+		geo := &MultiPolygon{Coords: Surfaces{}}
+		err := r.children(wkbPolygon, func(x Geometry) {
+			geo.Coords = append(geo.Coords, x.(*Polygon).Coords)
+		})
+		return geo, err
+
+	case wkbGeometryCollection:
+		geo := &GeometryCollection{Geometries: []Geometry{}}
+		err := r.children(0, func(x Geometry) {
+			geo.Geometries = append(geo.Geometries, x)
+		})
+		return geo, err
+
+	default:
+		return nil, r.fail("type %d is not supported", code)
+	}
+}
@@ -0,0 +1,132 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func TestMarshalWKB(t *testing.T) {
+	le, err := geojson.MarshalWKB(&geojson.Point{Coords: geojson.Coord{1, 2}}, binary.LittleEndian)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(hex.EncodeToString(le), "0101000000000000000000f03f0000000000000040"),
+	)
+
+	be, err := geojson.MarshalWKB(&geojson.Point{Coords: geojson.Coord{1, 2}}, binary.BigEndian)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(hex.EncodeToString(be), "00000000013ff00000000000004000000000000000"),
+	)
+
+	z, err := geojson.MarshalWKB(&geojson.Point{Coords: geojson.Coord{1, 2, 3}}, binary.LittleEndian)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(hex.EncodeToString(z[:5]), "01e9030000"),
+	)
+}
+
+func TestWKBCodec(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian, binary.NativeEndian} {
+		for _, geo := range []geojson.Geometry{
+			&geojson.Point{Coords: coordPoint},
+			&geojson.Point{Coords: geojson.Coord{100.0, 0.0, 10.0}},
+			&geojson.LineString{Coords: geojson.Curve{{100.0, 0.0, 10.0, 1.0}, {101.0, 1.0, 20.0, 2.0}}},
+			&geojson.Point{},
+			&geojson.MultiPoint{Coords: coordMultiPoint},
+			&geojson.LineString{Coords: coordLineString},
+			&geojson.MultiLineString{Coords: coordMultiLineString},
+			&geojson.Polygon{Coords: coordPolygonWithHole},
+			&geojson.MultiPolygon{Coords: coordMultiPolygon},
+			&geojson.GeometryCollection{
+				Geometries: []geojson.Geometry{
+					&geojson.Point{Coords: coordPoint},
+					&geojson.LineString{Coords: coordLineString},
+				},
+			},
+		} {
+			b, err := geojson.MarshalWKB(geo, order)
+			it.Then(t).Should(it.Nil(err))
+
+			x, err := geojson.UnmarshalWKB(b)
+			it.Then(t).Should(
+				it.Nil(err),
+				it.Equiv(x, geo),
+			)
+		}
+	}
+}
+
+func TestUnmarshalWKBInvalid(t *testing.T) {
+	for _, seq := range []string{
+		"",
+		"02",
+		"0101000000",
+		"0109000000000000000000f03f0000000000000040",
+		"0101000000000000000000f03f000000000000004000",
+		"010200000010000000",
+		"0104000000010000000102000000000000",
+	} {
+		b, _ := hex.DecodeString(seq)
+		_, err := geojson.UnmarshalWKB(b)
+		it.Then(t).Should(
+			it.Fail(func() error { return err }).Contain("invalid WKB"),
+		)
+	}
+
+	_, err := geojson.MarshalWKB(nil, binary.LittleEndian)
+	it.Then(t).Should(
+		it.Equal(err, error(geojson.ErrUnsupportedType)),
+	)
+}
+
+func TestWKBDimensions(t *testing.T) {
+	zm, err := geojson.MarshalWKB(&geojson.Point{Coords: geojson.Coord{1, 2, 3, 4}}, binary.LittleEndian)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(hex.EncodeToString(zm[:5]), "01b90b0000"),
+	)
+
+	_, err = geojson.MarshalWKB(&geojson.LineString{Coords: geojson.Curve{{1, 2, 3}, {4, 5}}}, binary.LittleEndian)
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrInvalidPosition)),
+		it.String(err.Error()).Contain("LineString mixes positions of 3 and 2 elements"),
+	)
+
+	// POINT M (1 2 3)
+	m, _ := hex.DecodeString("01d1070000000000000000f03f00000000000000400000000000000840")
+	_, err = geojson.UnmarshalWKB(m)
+	it.Then(t).Should(
+		it.True(errors.Is(err, geojson.ErrInvalidWKB)),
+		it.String(err.Error()).Contain("M variant of type 2001 is not supported"),
+	)
+}
+
+func TestWKBMultiPointEmptyMember(t *testing.T) {
+	b, err := geojson.MarshalWKB(&geojson.MultiPoint{Coords: geojson.Curve{{}, {1, 2}}}, binary.LittleEndian)
+	it.Then(t).Should(it.Nil(err))
+
+	geo, err := geojson.UnmarshalWKB(b)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(geo, geojson.Geometry(&geojson.MultiPoint{Coords: geojson.Curve{{1, 2}}})),
+	)
+
+	wkt, err := geojson.MarshalWKT(geo)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(wkt, "MULTIPOINT ((1 2))"),
+	)
+}
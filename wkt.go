@@ -0,0 +1,421 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MarshalWKT encodes geometry to Well-Known Text (WKT) format:
+//
+//	POINT (100 0)
+//	POLYGON ((100 0, 101 0, 101 1, 100 1, 100 0))
+//
+// Positions are written in lng, lat order following WKT convention.
+// Geometry of 3D positions is encoded as Z variant (e.g. POINT Z (100 0 10)),
+// 4D positions as ZM variant. All positions of geometry must have same
+// dimension, mixed ones are rejected with ErrInvalidPosition. The empty
+// position is written as EMPTY member of MULTIPOINT, it is rejected by
+// other geometries.
+func MarshalWKT(geo Geometry) (string, error) {
+	w := wktWriter{}
+	if err := w.geometry(geo); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
+
+type wktWriter struct {
+	strings.Builder
+	dim int
+}
+
+func (w *wktWriter) geometry(geo Geometry) error {
+	switch v := geo.(type) {
+	case *Point:
+		return w.tagged(typePoint, v.Coords, len(v.Coords) == 0, func() { w.coord(v.Coords) })
+	case *MultiPoint:
+		return w.tagged(typeMultiPoint, v.Coords, len(v.Coords) == 0, func() {
+			for i, c := range v.Coords {
+				w.sep(i)
+				if len(c) == 0 {
+					w.WriteString("EMPTY")
+					continue
+				}
+				w.WriteByte('(')
+				w.coord(c)
+				w.WriteByte(')')
+			}
+		})
+	case *LineString:
+		return w.tagged(typeLineString, v.Coords, len(v.Coords) == 0, func() { w.curve(v.Coords) })
+	case *MultiLineString:
+		return w.tagged(typeMultiLineString, v.Coords, len(v.Coords) == 0, func() { w.surface(v.Coords) })
+	case *Polygon:
+		return w.tagged(typePolygon, v.Coords, len(v.Coords) == 0, func() { w.surface(v.Coords) })
+	case *MultiPolygon:
+		return w.tagged(typeMultiPolygon, v.Coords, len(v.Coords) == 0, func() {
+			for i, surface := range v.Coords {
+				w.sep(i)
+				w.WriteByte('(')
+				w.surface(surface)
+				w.WriteByte(')')
+			}
+		})
+	case *GeometryCollection:
+		if len(v.Geometries) == 0 {
+			w.WriteString("GEOMETRYCOLLECTION EMPTY")
+			return nil
+		}
+
+		w.WriteString("GEOMETRYCOLLECTION (")
+		for i, x := range v.Geometries {
+			w.sep(i)
+			if err := w.geometry(x); err != nil {
+				return err
+			}
+		}
+		w.WriteByte(')')
+	default:
+		return ErrUnsupportedType
+	}
+
+	return nil
+}
+
+// tagged writes geometry tag, dimension and elements of geometry
+func (w *wktWriter) tagged(t geometryType, shape Shape, empty bool, elements func()) error {
+	w.WriteString(strings.ToUpper(string(t)))
+	if empty {
+		w.WriteString(" EMPTY")
+		return nil
+	}
+
+	dim, err := dimensionOf(t, shape)
+	if err != nil {
+		return err
+	}
+
+	// Note: only member of MultiPoint is empty point, it is written as EMPTY
+	if t != typeMultiPoint {
+		err := shape.FMapErr(func(c Coord) error {
+			if len(c) == 0 {
+				return fmt.Errorf("%w: %s has empty position", ErrInvalidPosition, t)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	w.dim = dim
+	switch w.dim {
+	case 3:
+		w.WriteString(" Z")
+	case 4:
+		w.WriteString(" ZM")
+	}
+
+	w.WriteString(" (")
+	elements()
+	w.WriteByte(')')
+	return nil
+}
+
+func (w *wktWriter) sep(i int) {
+	if i > 0 {
+		w.WriteString(", ")
+	}
+}
+
+func (w *wktWriter) coord(c Coord) {
+	for i := 0; i < w.dim; i++ {
+		if i > 0 {
+			w.WriteByte(' ')
+		}
+
+		w.WriteString(strconv.FormatFloat(c[i], 'f', -1, 64))
+	}
+}
+
+func (w *wktWriter) curve(seq Curve) {
+	i := 0
+	seq.FMap(func(c Coord) {
+		w.sep(i)
+		w.coord(c)
+		i++
+	})
+}
+
+func (w *wktWriter) surface(seq Surface) {
+	for i, curve := range seq {
+		w.sep(i)
+		w.WriteByte('(')
+		w.curve(curve)
+		w.WriteByte(')')
+	}
+}
+
+// UnmarshalWKT decodes geometry from Well-Known Text (WKT) format.
+// The empty member of MULTIPOINT (e.g. MULTIPOINT (EMPTY, (1 2))) is skipped.
+// Keywords are case-insensitive, Z and ZM variants are supported. The M
+// variant is rejected, the measure is 4th element of position that requires
+// altitude.
+func UnmarshalWKT(s string) (Geometry, error) {
+	r := wktReader{s: s}
+	geo, err := r.geometry()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := r.next(); tok != "" {
+		return nil, r.fail("unexpected %q", tok)
+	}
+
+	return geo, nil
+}
+
+type wktReader struct {
+	s   string
+	pos int
+}
+
+func (r *wktReader) fail(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at %d", ErrInvalidWKT, fmt.Sprintf(format, args...), r.pos)
+}
+
+// next token: word, number or one of punctuation symbols
+func (r *wktReader) next() string {
+	for r.pos < len(r.s) && unicode.IsSpace(rune(r.s[r.pos])) {
+		r.pos++
+	}
+
+	if r.pos == len(r.s) {
+		return ""
+	}
+
+	start := r.pos
+	switch r.s[r.pos] {
+	case '(', ')', ',':
+		r.pos++
+	default:
+		for r.pos < len(r.s) && !strings.ContainsRune("(), \t\r\n", rune(r.s[r.pos])) {
+			r.pos++
+		}
+	}
+
+	return r.s[start:r.pos]
+}
+
+// peek token without consuming it
+func (r *wktReader) peek() string {
+	pos := r.pos
+	tok := r.next()
+	r.pos = pos
+	return tok
+}
+
+func (r *wktReader) expect(tok string) error {
+	if x := r.next(); x != tok {
+		return r.fail("expected %q, got %q", tok, x)
+	}
+	return nil
+}
+
+// isEmpty consumes optional dimension tag and EMPTY keyword
+func (r *wktReader) isEmpty() bool {
+	switch strings.ToUpper(r.peek()) {
+	case "Z", "ZM":
+		r.next()
+	}
+
+	if strings.ToUpper(r.peek()) == "EMPTY" {
+		r.next()
+		return true
+	}
+	return false
+}
+
+func (r *wktReader) geometry() (Geometry, error) {
+	tag := strings.ToUpper(r.next())
+	if strings.ToUpper(r.peek()) == "M" {
+		return nil, r.fail("M variant of %s is not supported", tag)
+	}
+
+	switch tag {
+	case "POINT":
+		if r.isEmpty() {
+			return &Point{}, nil
+		}
+		if err := r.expect("("); err != nil {
+			return nil, err
+		}
+		c, err := r.coord()
+		if err != nil {
+			return nil, err
+		}
+		if err := r.expect(")"); err != nil {
+			return nil, err
+		}
+		return &Point{Coords: c}, nil
+
+	case "MULTIPOINT":
+		if r.isEmpty() {
+			return &MultiPoint{}, nil
+		}
+		seq, err := r.points()
+		return &MultiPoint{Coords: seq}, err
+
+	case "LINESTRING":
+		if r.isEmpty() {
+			return &LineString{}, nil
+		}
+		seq, err := r.curve()
+		return &LineString{Coords: seq}, err
+
+	case "MULTILINESTRING":
+		if r.isEmpty() {
+			return &MultiLineString{}, nil
+		}
+		seq, err := r.surface()
+		return &MultiLineString{Coords: seq}, err
+
+	case "POLYGON":
+		if r.isEmpty() {
+			return &Polygon{}, nil
+		}
+		seq, err := r.surface()
+		return &Polygon{Coords: seq}, err
+
+	case "MULTIPOLYGON":
+		if r.isEmpty() {
+			return &MultiPolygon{}, nil
+		}
+		seq := Surfaces{}
+		err := r.list(func() error {
+			surface, err := r.surface()
+			seq = append(seq, surface)
+			return err
+		})
+		return &MultiPolygon{Coords: seq}, err
+
+	case "GEOMETRYCOLLECTION":
+		if r.isEmpty() {
+			return &GeometryCollection{}, nil
+		}
+		seq := []Geometry{}
+		err := r.list(func() error {
+			geo, err := r.geometry()
+			seq = append(seq, geo)
+			return err
+		})
+		return &GeometryCollection{Geometries: seq}, err
+
+	default:
+		return nil, r.fail("type %q is not supported", tag)
+	}
+}
+
+// list of elements enclosed into parentheses and separated by comma
+func (r *wktReader) list(element func() error) error {
+	if err := r.expect("("); err != nil {
+		return err
+	}
+
+	for {
+		if err := element(); err != nil {
+			return err
+		}
+
+		switch tok := r.next(); tok {
+		case ",":
+			continue
+		case ")":
+			return nil
+		default:
+			return r.fail("expected \",\" or \")\", got %q", tok)
+		}
+	}
+}
+
+func (r *wktReader) coord() (Coord, error) {
+	c := Coord{}
+	for {
+		tok := r.peek()
+		if tok == "" || tok == "," || tok == ")" || tok == "(" {
+			break
+		}
+		r.next()
+
+		v, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return nil, r.fail("invalid number %q", tok)
+		}
+		c = append(c, v)
+	}
+
+	if len(c) < 2 || len(c) > 4 {
+		return nil, r.fail("invalid position")
+	}
+
+	return c, nil
+}
+
+func (r *wktReader) curve() (Curve, error) {
+	seq := Curve{}
+	err := r.list(func() error {
+		c, err := r.coord()
+		seq = append(seq, c)
+		return err
+	})
+	return seq, err
+}
+
+func (r *wktReader) surface() (Surface, error) {
+	seq := Surface{}
+	err := r.list(func() error {
+		curve, err := r.curve()
+		seq = append(seq, curve)
+		return err
+	})
+	return seq, err
+}
+
+// points of MULTIPOINT, positions are either enclosed in parentheses or not
+func (r *wktReader) points() (Curve, error) {
+	seq := Curve{}
+	err := r.list(func() error {
+		// Note: GeoJSON has no empty position, the member is skipped
+		if strings.ToUpper(r.peek()) == "EMPTY" {
+			r.next()
+			return nil
+		}
+
+		enclosed := r.peek() == "("
+		if enclosed {
+			r.next()
+		}
+
+		c, err := r.coord()
+		if err != nil {
+			return err
+		}
+		seq = append(seq, c)
+
+		if enclosed {
+			return r.expect(")")
+		}
+		return nil
+	})
+	return seq, err
+}
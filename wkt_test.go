@@ -0,0 +1,175 @@
+//
+// Copyright (C) 2021 Dmitry Kolesnikov
+//
+// This file may be modified and distributed under the terms
+// of the MIT license.  See the LICENSE file for details.
+// https://github.com/fogfish/geojson
+//
+
+package geojson_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fogfish/geojson"
+	"github.com/fogfish/it/v2"
+)
+
+func testMarshalWKT(t *testing.T, geo geojson.Geometry, expect string) {
+	t.Helper()
+
+	wkt, err := geojson.MarshalWKT(geo)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equal(wkt, expect),
+	)
+}
+
+func TestMarshalWKT(t *testing.T) {
+	testMarshalWKT(t, &geojson.Point{Coords: coordPoint}, "POINT (100 0)")
+	testMarshalWKT(t, &geojson.Point{Coords: geojson.Coord{100.5, 0.25, 10}}, "POINT Z (100.5 0.25 10)")
+	testMarshalWKT(t, &geojson.Point{Coords: geojson.Coord{100.5, 0.25, 10, 7}}, "POINT ZM (100.5 0.25 10 7)")
+	testMarshalWKT(t, &geojson.MultiPoint{Coords: coordMultiPoint}, "MULTIPOINT ((100 0), (101 1))")
+	testMarshalWKT(t, &geojson.LineString{Coords: coordLineString}, "LINESTRING (100 0, 101 1)")
+	testMarshalWKT(t, &geojson.MultiLineString{Coords: coordMultiLineString},
+		"MULTILINESTRING ((100 0, 101 1), (102 2, 103 3))")
+	testMarshalWKT(t, &geojson.Polygon{Coords: coordPolygonWithHole},
+		"POLYGON ((100 0, 101 0, 101 1, 100 1, 100 0), (100.8 0.8, 100.8 0.2, 100.2 0.2, 100.2 0.8, 100.8 0.8))")
+	testMarshalWKT(t, &geojson.MultiPolygon{Coords: coordMultiPolygon},
+		"MULTIPOLYGON (((102 2, 103 2, 103 3, 102 3, 102 2)), ((100 0, 101 0, 101 1, 100 1, 100 0), (100.2 0.2, 100.2 0.8, 100.8 0.8, 100.8 0.2, 100.2 0.2)))")
+	testMarshalWKT(t, &geojson.GeometryCollection{
+		Geometries: []geojson.Geometry{
+			&geojson.Point{Coords: coordPoint},
+			&geojson.LineString{Coords: coordLineString},
+		},
+	}, "GEOMETRYCOLLECTION (POINT (100 0), LINESTRING (100 0, 101 1))")
+}
+
+func TestMarshalWKTEmpty(t *testing.T) {
+	testMarshalWKT(t, &geojson.Point{}, "POINT EMPTY")
+	testMarshalWKT(t, &geojson.MultiPoint{}, "MULTIPOINT EMPTY")
+	testMarshalWKT(t, &geojson.LineString{}, "LINESTRING EMPTY")
+	testMarshalWKT(t, &geojson.MultiLineString{}, "MULTILINESTRING EMPTY")
+	testMarshalWKT(t, &geojson.Polygon{}, "POLYGON EMPTY")
+	testMarshalWKT(t, &geojson.MultiPolygon{}, "MULTIPOLYGON EMPTY")
+	testMarshalWKT(t, &geojson.GeometryCollection{}, "GEOMETRYCOLLECTION EMPTY")
+
+	_, err := geojson.MarshalWKT(nil)
+	it.Then(t).Should(
+		it.Equal(err, error(geojson.ErrUnsupportedType)),
+	)
+}
+
+func TestMarshalWKTEmptyPosition(t *testing.T) {
+	testMarshalWKT(t, &geojson.MultiPoint{Coords: geojson.Curve{{}, {1, 2}}}, "MULTIPOINT (EMPTY, (1 2))")
+	testMarshalWKT(t, &geojson.MultiPoint{Coords: geojson.Curve{{}}}, "MULTIPOINT (EMPTY)")
+
+	for _, geo := range []geojson.Geometry{
+		&geojson.LineString{Coords: geojson.Curve{{}, {1, 2}}},
+		&geojson.Polygon{Coords: geojson.Surface{{{1, 2}, {}, {3, 4}, {1, 2}}}},
+	} {
+		_, err := geojson.MarshalWKT(geo)
+		it.Then(t).Should(
+			it.True(errors.Is(err, geojson.ErrInvalidPosition)),
+		)
+	}
+
+	testUnmarshalWKT(t, "MULTIPOINT (EMPTY, (1 2))", &geojson.MultiPoint{Coords: geojson.Curve{{1, 2}}})
+	testUnmarshalWKT(t, "MULTIPOINT (empty)", &geojson.MultiPoint{Coords: geojson.Curve{}})
+}
+
+func TestMarshalWKTMixedDimensions(t *testing.T) {
+	for _, geo := range []geojson.Geometry{
+		&geojson.LineString{Coords: geojson.Curve{{100, 0}, {101, 1, 10}}},
+		&geojson.LineString{Coords: geojson.Curve{{100, 0, 10}, {101, 1, 10, 7}}},
+		&geojson.GeometryCollection{Geometries: []geojson.Geometry{
+			&geojson.Point{Coords: coordPoint},
+			&geojson.MultiPoint{Coords: geojson.Curve{{100, 0, 10}, {101, 1}}},
+		}},
+	} {
+		_, err := geojson.MarshalWKT(geo)
+		it.Then(t).Should(
+			it.True(errors.Is(err, geojson.ErrInvalidPosition)),
+		)
+	}
+}
+
+func testUnmarshalWKT(t *testing.T, wkt string, expect geojson.Geometry) {
+	t.Helper()
+
+	geo, err := geojson.UnmarshalWKT(wkt)
+	it.Then(t).Should(
+		it.Nil(err),
+		it.Equiv(geo, expect),
+	)
+}
+
+func TestUnmarshalWKT(t *testing.T) {
+	testUnmarshalWKT(t, "POINT (100 0)", &geojson.Point{Coords: coordPoint})
+	testUnmarshalWKT(t, "point z(100.5   0.25 10)", &geojson.Point{Coords: geojson.Coord{100.5, 0.25, 10}})
+	testUnmarshalWKT(t, "POINT ZM (100.5 0.25 10 7)", &geojson.Point{Coords: geojson.Coord{100.5, 0.25, 10, 7}})
+	testUnmarshalWKT(t, "MULTIPOINT ((100 0), (101 1))", &geojson.MultiPoint{Coords: coordMultiPoint})
+	testUnmarshalWKT(t, "MULTIPOINT (100 0, 101 1)", &geojson.MultiPoint{Coords: coordMultiPoint})
+	testUnmarshalWKT(t, "LineString(100 0,101 1)", &geojson.LineString{Coords: coordLineString})
+	testUnmarshalWKT(t, "MULTILINESTRING ((100 0, 101 1), (102 2, 103 3))",
+		&geojson.MultiLineString{Coords: coordMultiLineString})
+	testUnmarshalWKT(t, "POLYGON ((100 0, 101 0, 101 1, 100 1, 100 0),\n\t(100.8 0.8, 100.8 0.2, 100.2 0.2, 100.2 0.8, 100.8 0.8))",
+		&geojson.Polygon{Coords: coordPolygonWithHole})
+	testUnmarshalWKT(t, "MULTIPOLYGON (((102 2, 103 2, 103 3, 102 3, 102 2)), ((100 0, 101 0, 101 1, 100 1, 100 0), (100.2 0.2, 100.2 0.8, 100.8 0.8, 100.8 0.2, 100.2 0.2)))",
+		&geojson.MultiPolygon{Coords: coordMultiPolygon})
+	testUnmarshalWKT(t, "GEOMETRYCOLLECTION (POINT (100 0), GEOMETRYCOLLECTION EMPTY, LINESTRING (100 0, 101 1))",
+		&geojson.GeometryCollection{
+			Geometries: []geojson.Geometry{
+				&geojson.Point{Coords: coordPoint},
+				&geojson.GeometryCollection{},
+				&geojson.LineString{Coords: coordLineString},
+			},
+		},
+	)
+}
+
+func TestUnmarshalWKTEmpty(t *testing.T) {
+	testUnmarshalWKT(t, "POINT EMPTY", &geojson.Point{})
+	testUnmarshalWKT(t, "multipoint empty", &geojson.MultiPoint{})
+	testUnmarshalWKT(t, "LINESTRING Z EMPTY", &geojson.LineString{})
+	testUnmarshalWKT(t, "MULTILINESTRING EMPTY", &geojson.MultiLineString{})
+	testUnmarshalWKT(t, "POLYGON EMPTY", &geojson.Polygon{})
+	testUnmarshalWKT(t, "MULTIPOLYGON EMPTY", &geojson.MultiPolygon{})
+	testUnmarshalWKT(t, "GEOMETRYCOLLECTION EMPTY", &geojson.GeometryCollection{})
+}
+
+func TestUnmarshalWKTInvalid(t *testing.T) {
+	for _, wkt := range []string{
+		"",
+		"CIRCLE (1 2)",
+		"POINT (1 2",
+		"POINT (1 2))",
+		"POINT (1 x)",
+		"POINT (1)",
+		"LINESTRING (1 2, 3 4",
+		"POLYGON ((1 2, 3 4, 5 6, 1 2)",
+		"MULTIPOLYGON (((1 2, 3 4, 5 6, 1 2))",
+		"POINT M (1 2 3)",
+		"LINESTRING m (1 2 3, 4 5 6)",
+		"POLYGON M EMPTY",
+	} {
+		_, err := geojson.UnmarshalWKT(wkt)
+		it.Then(t).Should(
+			it.Fail(func() error { return err }).Contain("invalid WKT"),
+		)
+	}
+}
+
+func TestWKTCodec(t *testing.T) {
+	for _, geo := range []geojson.Geometry{
+		&geojson.Point{Coords: coordPoint},
+		&geojson.LineString{Coords: geojson.Curve{{100, 0, 10, 7}, {101, 1, 11, 8}}},
+		&geojson.MultiPolygon{Coords: coordMultiPolygon},
+	} {
+		wkt, err := geojson.MarshalWKT(geo)
+		it.Then(t).Should(it.Nil(err))
+
+		testUnmarshalWKT(t, wkt, geo)
+	}
+}